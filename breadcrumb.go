@@ -0,0 +1,92 @@
+package failure
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreadcrumbCapacity is the default number of breadcrumbs kept in memory.
+const BreadcrumbCapacity = 64
+
+// BreadcrumbEntry is an event recorded by Breadcrumb.
+type BreadcrumbEntry struct {
+	Time    time.Time `json:"time"`
+	Message string    `json:"message"`
+}
+
+func (b BreadcrumbEntry) String() string {
+	return b.Time.Format(time.RFC3339Nano) + " " + b.Message
+}
+
+var breadcrumbs = struct {
+	mu      sync.Mutex
+	entries []BreadcrumbEntry
+	next    int
+	full    bool
+}{
+	entries: make([]BreadcrumbEntry, BreadcrumbCapacity),
+}
+
+// Breadcrumb records an event leading up to a possible crash. Only the most recent
+// events are kept, see SetBreadcrumbCapacity, and they are included in crash reports.
+func Breadcrumb(message string, v ...any) {
+	entry := BreadcrumbEntry{
+		Time:    now(),
+		Message: message,
+	}
+	if len(v) > 0 {
+		entry.Message = fmt.Sprintf(message, v...)
+	}
+
+	breadcrumbs.mu.Lock()
+	defer breadcrumbs.mu.Unlock()
+
+	if len(breadcrumbs.entries) == 0 {
+		return
+	}
+
+	breadcrumbs.entries[breadcrumbs.next] = entry
+	breadcrumbs.next = (breadcrumbs.next + 1) % len(breadcrumbs.entries)
+	breadcrumbs.full = breadcrumbs.full || breadcrumbs.next == 0
+}
+
+// Breadcrumbs returns the recorded events, from the oldest to the most recent.
+func Breadcrumbs() []BreadcrumbEntry {
+	breadcrumbs.mu.Lock()
+	defer breadcrumbs.mu.Unlock()
+
+	return recorded()
+}
+
+// SetBreadcrumbCapacity changes the number of breadcrumbs kept, keeping the most recent ones.
+// A capacity of zero, or below, disables recording.
+func SetBreadcrumbCapacity(capacity int) {
+	capacity = max(capacity, 0)
+
+	breadcrumbs.mu.Lock()
+	defer breadcrumbs.mu.Unlock()
+
+	entries := recorded()
+	if len(entries) > capacity {
+		entries = entries[len(entries)-capacity:]
+	}
+
+	breadcrumbs.entries = make([]BreadcrumbEntry, capacity)
+	breadcrumbs.next = copy(breadcrumbs.entries, entries)
+	breadcrumbs.full = capacity > 0 && breadcrumbs.next == capacity
+	if breadcrumbs.full {
+		breadcrumbs.next = 0
+	}
+}
+
+func recorded() []BreadcrumbEntry {
+	if !breadcrumbs.full {
+		return append([]BreadcrumbEntry(nil), breadcrumbs.entries[:breadcrumbs.next]...)
+	}
+
+	return append(
+		append([]BreadcrumbEntry(nil), breadcrumbs.entries[breadcrumbs.next:]...),
+		breadcrumbs.entries[:breadcrumbs.next]...,
+	)
+}
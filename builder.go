@@ -3,17 +3,54 @@ package failure
 import (
 	"fmt"
 	"strconv"
+	"time"
 
+	"github.com/avila-r/failure/ctx"
+	"github.com/avila-r/failure/modifier"
+	"github.com/avila-r/failure/property"
+	"github.com/avila-r/failure/severity"
 	"github.com/avila-r/failure/stacktrace"
+	"github.com/avila-r/failure/tags"
 	"github.com/avila-r/failure/trail"
 )
 
 type ErrorBuilder struct {
-	class       *ErrorClass
-	message     string
-	cause       error
-	mode        stacktrace.BuildStackMode
+	class   *ErrorClass
+	message string
+	cause   error
+	mode    stacktrace.BuildStackMode
+	// skip is the number of frames between Build and the caller the stack trace starts at,
+	// for helpers building errors on behalf of their caller
+	skip        int
 	transparent bool
+	translucent bool
+	sealed      bool
+	properties  *property.List
+	ppc         uint8
+	underlying  bool
+	// complete is set once properties are given to the builder, making required ones enforced
+	complete bool
+
+	time     time.Time
+	duration time.Duration
+
+	domain  string
+	tags    tags.Tags
+	context ctx.Context
+
+	trace string
+	span  string
+
+	goroutine uint64
+	labels    map[string]string
+
+	returns  []uintptr
+	template *ErrorTemplate
+
+	hint     string
+	public   string
+	owner    string
+	severity severity.Severity
 }
 
 func Builder(c *ErrorClass) ErrorBuilder {
@@ -44,7 +81,27 @@ func BuilderFrom(err error) ErrorBuilder {
 					return stacktrace.TraceOmit
 				}
 			}(),
-			transparent: casted.Class().Modifiers.Transparent(),
+			transparent: casted.transparent,
+			translucent: casted.translucent,
+			sealed:      casted.sealed,
+			properties:  casted.properties,
+			ppc:         casted.ppc,
+			underlying:  casted.hasUnderlying,
+			time:        casted.time,
+			duration:    casted.duration,
+			domain:      casted.domain,
+			tags:        casted.tags,
+			context:     casted.context,
+			trace:       casted.trace,
+			span:        casted.span,
+			goroutine:   casted.goroutine,
+			labels:      casted.labels,
+			returns:     casted.returns,
+			template:    casted.template,
+			hint:        casted.hint,
+			public:      casted.public,
+			owner:       casted.owner,
+			severity:    casted.severity,
 		}
 	}
 
@@ -74,6 +131,12 @@ func (b ErrorBuilder) Cause(err error) ErrorBuilder {
 	return b
 }
 
+// skipping makes the stack trace start frames further up the caller of Build.
+func (b ErrorBuilder) skipping(frames int) ErrorBuilder {
+	b.skip += frames
+	return b
+}
+
 func (b ErrorBuilder) StackTrace() ErrorBuilder {
 	b.mode = stacktrace.TraceCollect
 	return b
@@ -84,6 +147,32 @@ func (b ErrorBuilder) Transparent() ErrorBuilder {
 		panic("wrong builder usage: wrap modifier without non-nil cause")
 	}
 	b.transparent = true
+	b.translucent = false
+	b.sealed = false
+	return b
+}
+
+// Translucent keeps the class of the error while exposing its cause to Is, As, Extends,
+// Has and Property, as transparent wrappers do, so that the error matches both.
+func (b ErrorBuilder) Translucent() ErrorBuilder {
+	if b.cause == nil {
+		panic("wrong builder usage: wrap modifier without non-nil cause")
+	}
+	b.transparent = false
+	b.translucent = true
+	b.sealed = false
+	return b
+}
+
+// Sealed hides the cause from matching: it is invisible to Is, As, Extends, Has
+// and Property, yet still rendered by %+v, Trail, JSON and logs.
+func (b ErrorBuilder) Sealed() ErrorBuilder {
+	if b.cause == nil {
+		panic("wrong builder usage: wrap modifier without non-nil cause")
+	}
+	b.transparent = false
+	b.translucent = false
+	b.sealed = true
 	return b
 }
 
@@ -108,14 +197,130 @@ func (b ErrorBuilder) Message(message string, v ...any) ErrorBuilder {
 	return b
 }
 
+func (b ErrorBuilder) With(key string, value any) ErrorBuilder {
+	b.complete = true
+	b.properties = b.properties.Set(key, value)
+	if b.ppc < 255 {
+		b.ppc++
+	}
+	return b
+}
+
+func (b ErrorBuilder) Time(t time.Time) ErrorBuilder {
+	b.time = t
+	return b
+}
+
+func (b ErrorBuilder) Duration(duration time.Duration) ErrorBuilder {
+	b.duration = duration
+	return b
+}
+
+// Since sets the duration to the time elapsed since t, or to zero when t lies in the future.
+func (b ErrorBuilder) Since(t time.Time) ErrorBuilder {
+	b.duration = elapsed(t)
+	return b
+}
+
+func (b ErrorBuilder) Domain(domain string) ErrorBuilder {
+	b.domain = domain
+	return b
+}
+
+func (b ErrorBuilder) Tags(tags tags.Tags) ErrorBuilder {
+	b.tags = tags
+	return b
+}
+
+func (b ErrorBuilder) Context(context ctx.Context) ErrorBuilder {
+	b.context = context
+	return b
+}
+
+func (b ErrorBuilder) Trace(trace string) ErrorBuilder {
+	b.trace = trace
+	return b
+}
+
+func (b ErrorBuilder) Span(span string) ErrorBuilder {
+	b.span = span
+	return b
+}
+
+func (b ErrorBuilder) Hint(hint string) ErrorBuilder {
+	b.hint = hint
+	return b
+}
+
+func (b ErrorBuilder) Public(public string) ErrorBuilder {
+	b.public = public
+	return b
+}
+
+func (b ErrorBuilder) Owner(owner string) ErrorBuilder {
+	b.owner = owner
+	return b
+}
+
+func (b ErrorBuilder) Severity(s severity.Severity) ErrorBuilder {
+	b.severity = s
+	return b
+}
+
 func (b ErrorBuilder) Build() *Error {
-	return &Error{
-		class:       b.class,
-		message:     b.message,
-		cause:       b.cause,
-		transparent: b.transparent,
-		stacktrace:  b.SetupStackTrace(),
-		trail:       trail.New(),
+	b.validate()
+
+	created := now()
+	err := &Error{
+		id:            generate(created),
+		created:       created,
+		class:         b.class,
+		message:       b.message,
+		cause:         b.cause,
+		transparent:   b.transparent,
+		translucent:   b.translucent,
+		sealed:        b.sealed,
+		properties:    b.properties,
+		ppc:           b.ppc,
+		hasUnderlying: b.underlying,
+		time:          b.time,
+		duration:      b.duration,
+		domain:        b.domain,
+		tags:          b.tags,
+		context:       b.context,
+		trace:         b.trace,
+		span:          b.span,
+		goroutine:     b.goroutine,
+		labels:        b.labels,
+		returns:       b.returns[:len(b.returns):len(b.returns)],
+		template:      b.template,
+		hint:          b.hint,
+		public:        b.public,
+		owner:         b.owner,
+		severity:      b.severity,
+		stacktrace:    b.SetupStackTrace(5 + b.skip),
+		trail:         trail.New(),
+	}
+
+	if modifier.RecordGoroutine(b.class.Modifiers) && err.goroutine == 0 {
+		err.goroutine = goroutine()
+	}
+
+	return err.conform(b.complete)
+}
+
+// validate panics on field combinations which can't make a consistent error.
+func (b ErrorBuilder) validate() {
+	if b.class == nil {
+		panic("wrong builder usage: nil class")
+	}
+
+	if b.duration < 0 {
+		panic("wrong builder usage: negative duration " + b.duration.String())
+	}
+
+	if b.severity < severity.Unset || b.severity > severity.Critical {
+		panic("wrong builder usage: unknown severity " + strconv.Itoa(int(b.severity)))
 	}
 }
 
@@ -131,7 +336,7 @@ func (b ErrorBuilder) SetupStackTrace(skip ...int) *stacktrace.StackTrace {
 			return st
 		}
 
-		return stacktrace.Collect()
+		return stacktrace.Collect(skip...)
 	case stacktrace.TraceEnhance:
 		current, initial := stacktrace.Collect(skip...), b.collect(b.cause)
 		if initial != nil {
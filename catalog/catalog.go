@@ -0,0 +1,219 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/modifier"
+	"github.com/avila-r/failure/severity"
+	"github.com/avila-r/failure/trait"
+)
+
+// Environment is the variable which makes ExportIfRequested write the catalog
+// to the path it holds, or to the standard output when it is "-".
+const Environment = "FAILURE_CATALOG"
+
+// Catalog describes every namespace and class registered by a program.
+type Catalog struct {
+	Namespaces []Namespace `json:"namespaces"`
+}
+
+type Namespace struct {
+	Name      string   `json:"name"`
+	Parent    string   `json:"parent,omitempty"`
+	Traits    []string `json:"traits,omitempty"`
+	Modifiers []string `json:"modifiers,omitempty"`
+	// Classes are the classes declared directly in the namespace, subclasses included
+	Classes []Class `json:"classes,omitempty"`
+}
+
+type Class struct {
+	Name        string     `json:"name"`
+	Parent      string     `json:"parent,omitempty"`
+	Traits      []string   `json:"traits,omitempty"`
+	Modifiers   []string   `json:"modifiers,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Docs        string     `json:"docs,omitempty"`
+	Code        string     `json:"code,omitempty"`
+	Status      int        `json:"status,omitempty"`
+	Severity    string     `json:"severity,omitempty"`
+	Properties  []Property `json:"properties,omitempty"`
+}
+
+// Property is a property declared by the schema of a class.
+type Property struct {
+	Key      string `json:"key"`
+	Type     string `json:"type,omitempty"`
+	Required bool   `json:"required,omitempty"`
+}
+
+// Collect describes the namespaces and classes registered so far, in creation order,
+// every namespace being followed by its subnamespaces.
+func Collect() Catalog {
+	catalog := Catalog{
+		Namespaces: []Namespace{},
+	}
+
+	var visit func(nodes []*failure.Node)
+	visit = func(nodes []*failure.Node) {
+		for _, node := range nodes {
+			if node.Namespace == nil {
+				continue
+			}
+
+			namespace := describeNamespace(*node.Namespace)
+			namespace.Classes = classes(node.Children)
+			catalog.Namespaces = append(catalog.Namespaces, namespace)
+
+			visit(node.Children)
+		}
+	}
+
+	visit(failure.Tree())
+	return catalog
+}
+
+// classes describes the classes among nodes, each one followed by its subclasses.
+func classes(nodes []*failure.Node) []Class {
+	var result []Class
+	for _, node := range nodes {
+		if node.Class == nil {
+			continue
+		}
+
+		result = append(result, describeClass(node.Class))
+		result = append(result, classes(node.Children)...)
+	}
+	return result
+}
+
+func describeNamespace(n failure.ErrorNamespace) Namespace {
+	namespace := Namespace{
+		Name:      n.Name,
+		Traits:    labels(n.Traits...),
+		Modifiers: modifiers(n.Modifiers),
+	}
+
+	if n.Parent != nil {
+		namespace.Parent = n.Parent.Name
+	}
+
+	return namespace
+}
+
+func describeClass(c *failure.ErrorClass) Class {
+	class := Class{
+		Name:        c.Name,
+		Modifiers:   modifiers(c.Modifiers),
+		Description: c.Description,
+		Docs:        c.Docs,
+		Code:        c.Code,
+		Status:      c.Status,
+	}
+
+	if c.Parent != nil {
+		class.Parent = c.Parent.Name
+	}
+
+	for t := range c.Traits {
+		class.Traits = append(class.Traits, t.Label)
+	}
+	slices.Sort(class.Traits)
+
+	if c.Severity != severity.Unset {
+		class.Severity = c.Severity.String()
+	}
+
+	if c.Schema != nil {
+		for _, field := range c.Schema.Fields {
+			property := Property{
+				Key:      field.Key,
+				Required: field.Required,
+			}
+			if field.Type != nil {
+				property.Type = field.Type.String()
+			}
+			class.Properties = append(class.Properties, property)
+		}
+	}
+
+	return class
+}
+
+func labels(traits ...trait.Trait) []string {
+	result := make([]string, 0, len(traits))
+	for _, t := range traits {
+		result = append(result, t.Label)
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
+
+func modifiers(m modifier.Modifiers) []string {
+	if m == nil {
+		return nil
+	}
+
+	result := []string{}
+	if m.Transparent() {
+		result = append(result, "transparent")
+	}
+	if !m.CollectStackTrace() {
+		result = append(result, "omit_stack_trace")
+	}
+	if modifier.RecordGoroutine(m) {
+		result = append(result, "goroutine")
+	}
+	return result
+}
+
+// Read decodes a catalog written in the JSON format.
+func Read(r io.Reader) (Catalog, error) {
+	catalog := Catalog{}
+	err := json.NewDecoder(r).Decode(&catalog)
+	return catalog, err
+}
+
+// ExportIfRequested writes the catalog of the program in the JSON format and exits
+// when the Environment variable is set. It is meant to be called first thing in main,
+// once every package-level class is registered, so that cmd/failure-catalog can run the program:
+//
+//	func main() {
+//		catalog.ExportIfRequested()
+//		// ...
+//	}
+func ExportIfRequested() {
+	path, ok := os.LookupEnv(Environment)
+	if !ok || path == "" {
+		return
+	}
+
+	if err := export(path); err != nil {
+		fmt.Fprintf(os.Stderr, "failure: unable to export the error catalog: %v\n", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func export(path string) error {
+	if path == "-" {
+		return Collect().JSON(os.Stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := Collect().JSON(file); err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
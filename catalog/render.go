@@ -0,0 +1,175 @@
+package catalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/avila-r/failure"
+)
+
+// Formats lists the formats supported by Render.
+var Formats = []string{"markdown", "html", "json"}
+
+// Render writes the catalog in format, one of Formats.
+func (c Catalog) Render(w io.Writer, format string) error {
+	switch strings.ToLower(format) {
+	case "markdown", "md":
+		return c.Markdown(w)
+	case "html":
+		return c.HTML(w)
+	case "json":
+		return c.JSON(w)
+	default:
+		return failure.UnsupportedOperation.New("unsupported catalog format %q, expected one of %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+func (c Catalog) JSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
+
+func (c Catalog) Markdown(w io.Writer) error {
+	text := strings.Builder{}
+	text.WriteString("# Error catalog\n")
+
+	for _, namespace := range c.Namespaces {
+		fmt.Fprintf(&text, "\n## %s\n\n", cell(namespace.Name))
+
+		if namespace.Parent != "" {
+			fmt.Fprintf(&text, "Parent: `%s`\n\n", namespace.Parent)
+		}
+		if len(namespace.Traits) > 0 {
+			fmt.Fprintf(&text, "Traits: %s\n\n", strings.Join(namespace.Traits, ", "))
+		}
+		if len(namespace.Modifiers) > 0 {
+			fmt.Fprintf(&text, "Modifiers: %s\n\n", strings.Join(namespace.Modifiers, ", "))
+		}
+
+		if len(namespace.Classes) == 0 {
+			text.WriteString("No classes.\n")
+			continue
+		}
+
+		text.WriteString("| Class | Parent | Code | Status | Severity | Traits | Modifiers | Properties | Description |\n")
+		text.WriteString("|---|---|---|---|---|---|---|---|---|\n")
+
+		for _, class := range namespace.Classes {
+			name := "`" + cell(class.Name) + "`"
+			if class.Docs != "" {
+				name = "[" + name + "](" + class.Docs + ")"
+			}
+
+			fmt.Fprintf(&text, "| %s | %s | %s | %s | %s | %s | %s | %s | %s |\n",
+				name,
+				cell(class.Parent),
+				cell(class.Code),
+				status(class.Status),
+				cell(class.Severity),
+				cell(strings.Join(class.Traits, ", ")),
+				cell(strings.Join(class.Modifiers, ", ")),
+				cell(properties(class.Properties)),
+				cell(class.Description),
+			)
+		}
+	}
+
+	_, err := io.WriteString(w, text.String())
+	return err
+}
+
+// cell escapes text for a Markdown table cell.
+func cell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\n", " ").Replace(text)
+}
+
+func status(code int) string {
+	if code == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("%d %s", code, http.StatusText(code))
+}
+
+func properties(list []Property) string {
+	strs := make([]string, len(list))
+	for i, p := range list {
+		strs[i] = p.String()
+	}
+	return strings.Join(strs, ", ")
+}
+
+func (p Property) String() string {
+	text := p.Key
+	if p.Type != "" {
+		text += " " + p.Type
+	}
+	if p.Required {
+		text += " (required)"
+	}
+	return text
+}
+
+var page = template.Must(template.New("catalog").Funcs(template.FuncMap{
+	"join":       strings.Join,
+	"status":     status,
+	"properties": properties,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Error catalog</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 0.4em; text-align: left; vertical-align: top; }
+th { background: #f4f4f4; }
+code { white-space: nowrap; }
+</style>
+</head>
+<body>
+<h1>Error catalog</h1>
+{{- range .Namespaces}}
+<h2 id="{{.Name}}">{{.Name}}</h2>
+{{- if .Parent}}
+<p>Parent: <a href="#{{.Parent}}"><code>{{.Parent}}</code></a></p>
+{{- end}}
+{{- if .Traits}}
+<p>Traits: {{join .Traits ", "}}</p>
+{{- end}}
+{{- if .Modifiers}}
+<p>Modifiers: {{join .Modifiers ", "}}</p>
+{{- end}}
+{{- if .Classes}}
+<table>
+<tr><th>Class</th><th>Parent</th><th>Code</th><th>Status</th><th>Severity</th><th>Traits</th><th>Modifiers</th><th>Properties</th><th>Description</th></tr>
+{{- range .Classes}}
+<tr id="{{.Name}}">
+<td>{{if .Docs}}<a href="{{.Docs}}"><code>{{.Name}}</code></a>{{else}}<code>{{.Name}}</code>{{end}}</td>
+<td>{{if .Parent}}<a href="#{{.Parent}}"><code>{{.Parent}}</code></a>{{end}}</td>
+<td>{{.Code}}</td>
+<td>{{status .Status}}</td>
+<td>{{.Severity}}</td>
+<td>{{join .Traits ", "}}</td>
+<td>{{join .Modifiers ", "}}</td>
+<td>{{properties .Properties}}</td>
+<td>{{.Description}}</td>
+</tr>
+{{- end}}
+</table>
+{{- else}}
+<p>No classes.</p>
+{{- end}}
+{{- end}}
+</body>
+</html>
+`))
+
+func (c Catalog) HTML(w io.Writer) error {
+	return page.Execute(w, c)
+}
@@ -3,6 +3,8 @@ package failure
 import (
 	"time"
 
+	"github.com/avila-r/failure/ctx"
+	"github.com/avila-r/failure/severity"
 	"github.com/avila-r/failure/tags"
 )
 
@@ -44,6 +46,11 @@ func (c *ErrorChain) Tags(tags tags.Tags) *ErrorChain {
 	return c
 }
 
+func (c *ErrorChain) Context(context ctx.Context) *ErrorChain {
+	c.err.context = context
+	return c
+}
+
 func (c *ErrorChain) In(domain string) *ErrorChain {
 	c.err.domain = domain
 	return c
@@ -55,7 +62,12 @@ func (c *ErrorChain) Duration(duration time.Duration) *ErrorChain {
 }
 
 func (c *ErrorChain) Since(t time.Time) *ErrorChain {
-	c.err.duration = time.Since(t)
+	c.err.duration = elapsed(t)
+	return c
+}
+
+func (c *ErrorChain) Severity(s severity.Severity) *ErrorChain {
+	c.err.severity = s
 	return c
 }
 
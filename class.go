@@ -6,6 +6,8 @@ import (
 
 	"github.com/avila-r/failure/id"
 	"github.com/avila-r/failure/modifier"
+	"github.com/avila-r/failure/schema"
+	"github.com/avila-r/failure/severity"
 	"github.com/avila-r/failure/trait"
 )
 
@@ -16,6 +18,65 @@ type ErrorClass struct {
 	Name      string
 	Traits    map[trait.Trait]bool
 	Modifiers modifier.Modifiers
+	Schema    *schema.Schema
+
+	// Description is a human readable explanation of the class
+	Description string
+	// Docs is a link to the documentation of the class
+	Docs string
+	// Severity is the default severity of errors of the class
+	Severity severity.Severity
+	// Code is a stable public identifier, unique across the registry and not inherited by subclasses
+	Code string
+	// Status is the default HTTP status of errors of the class
+	Status int
+}
+
+// ClassOption configures an error class upon creation.
+type ClassOption func(*ErrorClass)
+
+func WithTraits(traits ...trait.Trait) ClassOption {
+	return func(c *ErrorClass) {
+		for _, trait := range traits {
+			c.Traits[trait] = true
+		}
+	}
+}
+
+func WithDescription(description string) ClassOption {
+	return func(c *ErrorClass) {
+		c.Description = description
+	}
+}
+
+func WithDocs(url string) ClassOption {
+	return func(c *ErrorClass) {
+		c.Docs = url
+	}
+}
+
+func WithSeverity(s severity.Severity) ClassOption {
+	return func(c *ErrorClass) {
+		c.Severity = s
+	}
+}
+
+func WithCode(code string) ClassOption {
+	return func(c *ErrorClass) {
+		c.Code = code
+	}
+}
+
+func WithStatus(status int) ClassOption {
+	return func(c *ErrorClass) {
+		c.Status = status
+	}
+}
+
+func WithSchema(fields ...schema.Field) ClassOption {
+	return func(c *ErrorClass) {
+		c.Schema = c.Schema.Extend(fields...)
+	}
 }
 
 func (c *ErrorClass) Of(message string, v ...any) *Error {
@@ -28,6 +89,11 @@ func (c *ErrorClass) New(message string, v ...any) *Error {
 		Build()
 }
 
+// Sentinel creates a frozen error of the class, see (*Error).Freeze.
+func (c *ErrorClass) Sentinel(message string, v ...any) *Error {
+	return c.New(message, v...).Freeze()
+}
+
 func (c *ErrorClass) Blank() *Error {
 	return Builder(c).
 		Build()
@@ -46,7 +112,7 @@ func (c *ErrorClass) From(err error) *Error {
 		Build()
 }
 
-func Class(name string, traits ...trait.Trait) *ErrorClass {
+func Class(name string, options ...ClassOption) *ErrorClass {
 	class := &ErrorClass{
 		Namespace: DefaultNamespace,
 		Parent:    nil,
@@ -57,20 +123,19 @@ func Class(name string, traits ...trait.Trait) *ErrorClass {
 			for trait := range DefaultNamespace.CollectTraits() {
 				result[trait] = true
 			}
-			for _, trait := range traits {
-				result[trait] = true
-			}
 			return result
 		}(),
 		Modifiers: modifier.Inherited(DefaultNamespace.Modifiers),
+		Severity:  DefaultNamespace.Severity,
 	}
 
+	class.configure(options...)
 	class.register()
 
 	return class
 }
 
-func (c ErrorClass) Class(name string, traits ...trait.Trait) *ErrorClass {
+func (c ErrorClass) Class(name string, options ...ClassOption) *ErrorClass {
 	class := &ErrorClass{
 		Namespace: c.Namespace,
 		Parent:    &c,
@@ -90,14 +155,17 @@ func (c ErrorClass) Class(name string, traits ...trait.Trait) *ErrorClass {
 			for trait := range c.Namespace.CollectTraits() {
 				result[trait] = true
 			}
-			for _, trait := range traits {
-				result[trait] = true
-			}
 			return result
 		}(),
-		Modifiers: modifier.Inherited(c.Modifiers),
+		Modifiers:   modifier.Inherited(c.Modifiers),
+		Schema:      c.Schema,
+		Description: c.Description,
+		Docs:        c.Docs,
+		Severity:    c.Severity.Or(c.Namespace.Severity),
+		Status:      c.Status,
 	}
 
+	class.configure(options...)
 	class.register()
 
 	return class
@@ -125,6 +193,13 @@ func (c *ErrorClass) Apply(modifiers ...modifier.ClassModifier) *ErrorClass {
 	return c
 }
 
+// Expect declares properties that errors of this class, and of its subclasses
+// created afterwards, are validated against when built or enriched through With.
+func (c *ErrorClass) Expect(fields ...schema.Field) *ErrorClass {
+	c.Schema = c.Schema.Extend(fields...)
+	return c
+}
+
 func (c *ErrorClass) String() string {
 	return c.Name
 }
@@ -137,14 +212,26 @@ func (c *ErrorClass) RootNamespace() ErrorNamespace {
 	return n
 }
 
+func (c *ErrorClass) configure(options ...ClassOption) {
+	for _, option := range options {
+		option(c)
+	}
+}
+
 func (c *ErrorClass) register() {
 	Registry.mu.Lock()
 	defer Registry.mu.Unlock()
 
-	Registry.Classes = append(Registry.Classes, c)
-	for _, s := range Registry.Listeners {
-		s.OnClassCreated(c)
+	if c.Code != "" {
+		for _, other := range Registry.Classes {
+			if other.Code == c.Code {
+				panic("error code " + c.Code + " is already used by class " + other.Name)
+			}
+		}
 	}
+
+	Registry.Classes = append(Registry.Classes, c)
+	publish(event{class: c})
 }
 
 var _ encoding.TextMarshaler = (*ErrorClass)(nil)
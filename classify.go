@@ -0,0 +1,176 @@
+package failure
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"slices"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/avila-r/failure/trait"
+)
+
+// classifier maps foreign errors to a class and additional traits.
+type classifier struct {
+	match  func(error) bool
+	class  *ErrorClass
+	traits []trait.Trait
+}
+
+// classification is the class and traits a foreign error is considered to have.
+type classification struct {
+	class  *ErrorClass
+	traits []trait.Trait
+}
+
+func (c classification) Has(t trait.Trait) bool {
+	return c.class.Has(t) || slices.Contains(c.traits, t)
+}
+
+var classifiers = struct {
+	mu   sync.RWMutex
+	list []classifier
+}{}
+
+// Classify makes foreign errors matching target through errors.Is part of class,
+// with additional traits. A nil class only adds traits.
+// Classifiers are consulted in registration order, before the built-in ones.
+func Classify(target error, class *ErrorClass, traits ...trait.Trait) {
+	ClassifyFunc(func(err error) bool {
+		return errors.Is(err, target)
+	}, class, traits...)
+}
+
+// ClassifyType makes foreign errors holding a T, as found by errors.As, part of class.
+func ClassifyType[T error](class *ErrorClass, traits ...trait.Trait) {
+	ClassifyFunc(func(err error) bool {
+		var target T
+		return errors.As(err, &target)
+	}, class, traits...)
+}
+
+// ClassifyFunc makes foreign errors satisfying predicate part of class.
+func ClassifyFunc(predicate func(error) bool, class *ErrorClass, traits ...trait.Trait) {
+	classifiers.mu.Lock()
+	defer classifiers.mu.Unlock()
+
+	classifiers.list = append(classifiers.list, classifier{
+		match:  predicate,
+		class:  class,
+		traits: traits,
+	})
+}
+
+// builtins classify errors of the standard library.
+var builtins = []classifier{
+	is(context.DeadlineExceeded, TimeoutElapsed),
+	is(context.Canceled, Interrupted),
+	is(os.ErrDeadlineExceeded, TimeoutElapsed),
+	{
+		match: func(err error) bool {
+			var target net.Error
+			return errors.As(err, &target) && target.Timeout()
+		},
+		class: TimeoutElapsed,
+	},
+	is(fs.ErrNotExist, DataUnavailable, trait.NotFound),
+	is(fs.ErrExist, RejectedOperation, trait.Duplicate),
+	is(fs.ErrPermission, RejectedOperation),
+	is(fs.ErrClosed, IllegalState),
+	is(net.ErrClosed, IllegalState),
+	is(sql.ErrNoRows, DataUnavailable, trait.NotFound),
+	is(sql.ErrConnDone, IllegalState),
+	is(sql.ErrTxDone, IllegalState),
+	is(io.ErrUnexpectedEOF, IllegalFormat),
+	is(io.ErrClosedPipe, IllegalState),
+	is(strconv.ErrSyntax, IllegalFormat),
+	is(strconv.ErrRange, IllegalArgument),
+	as[*json.SyntaxError](IllegalFormat),
+	as[*json.UnmarshalTypeError](IllegalFormat),
+	{
+		match: func(err error) bool {
+			var target *net.OpError
+			return errors.As(err, &target) &&
+				(errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED))
+		},
+		class:  ExternalError,
+		traits: []trait.Trait{trait.Temporary},
+	},
+	as[*net.OpError](ExternalError),
+	as[*net.DNSError](ExternalError),
+}
+
+func is(target error, class *ErrorClass, traits ...trait.Trait) classifier {
+	return classifier{
+		match: func(err error) bool {
+			return errors.Is(err, target)
+		},
+		class:  class,
+		traits: traits,
+	}
+}
+
+func as[T error](class *ErrorClass, traits ...trait.Trait) classifier {
+	return classifier{
+		match: func(err error) bool {
+			var target T
+			return errors.As(err, &target)
+		},
+		class:  class,
+		traits: traits,
+	}
+}
+
+// classify returns the class and traits a foreign error is considered to have.
+func classify(err error) classification {
+	if err == nil {
+		return classification{class: foreignClass}
+	}
+
+	// classifiers are only ever appended, so the snapshot stays valid
+	// while predicates run outside the lock
+	classifiers.mu.RLock()
+	registered := classifiers.list
+	classifiers.mu.RUnlock()
+
+	for _, list := range [][]classifier{registered, builtins} {
+		for _, c := range list {
+			if c.match(err) {
+				if c.class == nil {
+					return classification{class: foreignClass, traits: c.traits}
+				}
+				return classification{class: c.class, traits: c.traits}
+			}
+		}
+	}
+
+	return classification{class: foreignClass}
+}
+
+// CancelCause returns the cause of the cancellation of c when err results from it
+// and that cause is an *Error, such as one passed to the cancel function returned by
+// context.WithCancelCause, so that a classified error survives the cancellation.
+// Otherwise, err is returned unchanged.
+func CancelCause(c context.Context, err error) error {
+	if err == nil || c.Err() == nil {
+		return err
+	}
+
+	if !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+
+	var cause *Error
+	if errors.As(context.Cause(c), &cause) {
+		return cause
+	}
+
+	return err
+}
@@ -0,0 +1,65 @@
+package failure
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/avila-r/failure/clock"
+	"github.com/avila-r/failure/id"
+)
+
+var (
+	clocks     atomic.Pointer[clock.Clock]
+	generators atomic.Pointer[id.Generator]
+)
+
+func init() {
+	SetClock(clock.System)
+	SetIDGenerator(id.Random)
+}
+
+// SetClock replaces the clock used to stamp errors at creation and returns the previous one.
+// A nil clock restores the system one.
+func SetClock(c clock.Clock) clock.Clock {
+	if c == nil {
+		c = clock.System
+	}
+
+	if old := clocks.Swap(&c); old != nil {
+		return *old
+	}
+	return nil
+}
+
+// SetIDGenerator replaces the generator of error instance identifiers and returns the previous one.
+// A nil generator restores the random one.
+func SetIDGenerator(g id.Generator) id.Generator {
+	if g == nil {
+		g = id.Random
+	}
+
+	if old := generators.Swap(&g); old != nil {
+		return *old
+	}
+	return nil
+}
+
+func now() time.Time {
+	if c := clocks.Load(); c != nil {
+		return (*c).Now()
+	}
+	return time.Now()
+}
+
+// elapsed returns the time elapsed since t, clamped to zero when t lies in the future,
+// such as one read from a skewed clock.
+func elapsed(t time.Time) time.Duration {
+	return max(now().Sub(t), 0)
+}
+
+func generate(at time.Time) string {
+	if g := generators.Load(); g != nil {
+		return (*g).Generate(at)
+	}
+	return id.Random.Generate(at)
+}
@@ -0,0 +1,65 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+type Clock interface {
+	Now() time.Time
+}
+
+type (
+	system struct{}
+)
+
+var (
+	// System is the clock backed by time.Now
+	System Clock = system{}
+)
+
+// Now implements Clock.
+func (system) Now() time.Time {
+	return time.Now()
+}
+
+// Func adapts an ordinary function to the Clock interface.
+type Func func() time.Time
+
+// Now implements Clock.
+func (f Func) Now() time.Time {
+	return f()
+}
+
+// Fake is a manually driven clock, meant for tests.
+type Fake struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+var (
+	_ Clock = (*Fake)(nil)
+)
+
+func NewFake(now time.Time) *Fake {
+	return &Fake{now: now}
+}
+
+// Now implements Clock.
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+func (f *Fake) Set(now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = now
+}
+
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.now = f.now.Add(d)
+}
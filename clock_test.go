@@ -0,0 +1,44 @@
+package failure_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/clock"
+	"github.com/avila-r/failure/id"
+)
+
+func TestIDIdentifiesOccurrence(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	defer failure.SetClock(failure.SetClock(clock.NewFake(at)))
+	defer failure.SetIDGenerator(failure.SetIDGenerator(id.Sequence("err-")))
+
+	err := failure.DataUnavailable.New("record not found")
+	if err.ID() != "err-1" || !err.Time().Equal(at) {
+		t.Fatalf("unexpected id %q and time %v", err.ID(), err.Time())
+	}
+
+	if derived := err.With("request", 1).WithOwner("owner"); derived.ID() != err.ID() {
+		t.Fatalf("enrichment changed the id from %q to %q", err.ID(), derived.ID())
+	}
+
+	sentinel := failure.DataUnavailable.Sentinel("record not found")
+	if derived := sentinel.With("request", 1); derived.ID() == sentinel.ID() {
+		t.Fatalf("a copy of the sentinel shares its id %q", sentinel.ID())
+	}
+}
+
+func TestSinceFutureIsZero(t *testing.T) {
+	at := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	defer failure.SetClock(failure.SetClock(clock.NewFake(at)))
+
+	err := failure.Builder(failure.TimeoutElapsed).Since(at.Add(time.Second)).Build()
+	if err.Duration() != 0 {
+		t.Fatalf("unexpected duration %v for a start in the future", err.Duration())
+	}
+
+	if failure.Try(func() { failure.Builder(failure.TimeoutElapsed).Duration(-time.Second).Build() }) == nil {
+		t.Fatal("an explicit negative duration did not panic")
+	}
+}
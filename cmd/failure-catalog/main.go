@@ -0,0 +1,145 @@
+// Command failure-catalog publishes the error classes a program can return
+// as a Markdown, HTML or JSON catalog.
+//
+// Classes are loaded in one of the following ways:
+//
+//	-pkg ./internal/errors,./api   importing the packages, from a generated program, registers their classes
+//	-run ./cmd/server              running a main package which calls catalog.ExportIfRequested
+//	-in catalog.json               reading a catalog previously exported in the JSON format
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/avila-r/failure/catalog"
+)
+
+func main() {
+	var (
+		pkg    = flag.String("pkg", "", "comma-separated packages registering error classes, imported by a generated program")
+		run    = flag.String("run", "", "main package calling catalog.ExportIfRequested")
+		in     = flag.String("in", "", "catalog in the JSON format, - for the standard input")
+		format = flag.String("format", "markdown", "output format, one of "+strings.Join(catalog.Formats, ", "))
+		out    = flag.String("out", "", "output file, the standard output by default")
+	)
+	flag.Parse()
+
+	if err := generate(*pkg, *run, *in, *format, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "failure-catalog: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func generate(pkg, run, in, format, out string) error {
+	c, err := load(pkg, run, in)
+	if err != nil {
+		return err
+	}
+
+	w := io.Writer(os.Stdout)
+	if out != "" {
+		file, err := os.Create(out)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	return c.Render(w, format)
+}
+
+func load(pkg, run, in string) (catalog.Catalog, error) {
+	switch {
+	case pkg != "":
+		return imported(strings.Split(pkg, ","))
+	case run != "":
+		return exported(run)
+	case in == "-":
+		return catalog.Read(os.Stdin)
+	case in != "":
+		file, err := os.Open(in)
+		if err != nil {
+			return catalog.Catalog{}, err
+		}
+		defer file.Close()
+		return catalog.Read(file)
+	default:
+		return catalog.Catalog{}, fmt.Errorf("one of -pkg, -run or -in is required")
+	}
+}
+
+const hook = `// Code generated by failure-catalog. DO NOT EDIT.
+
+package main
+
+import (
+	"github.com/avila-r/failure/catalog"
+{{imports}})
+
+func main() {
+	catalog.ExportIfRequested()
+}
+`
+
+// imported generates, within the current module, a program importing packages
+// for their side effects and runs it to export their classes.
+func imported(packages []string) (catalog.Catalog, error) {
+	paths, err := command("go", append([]string{"list", "-f", "{{.ImportPath}}"}, packages...)...)
+	if err != nil {
+		return catalog.Catalog{}, err
+	}
+
+	imports := strings.Builder{}
+	for _, path := range strings.Fields(string(paths)) {
+		fmt.Fprintf(&imports, "\t_ %q\n", path)
+	}
+
+	dir, err := os.MkdirTemp(".", ".failure-catalog-")
+	if err != nil {
+		return catalog.Catalog{}, err
+	}
+	defer os.RemoveAll(dir)
+
+	source := strings.Replace(hook, "{{imports}}", imports.String(), 1)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(source), 0o644); err != nil {
+		return catalog.Catalog{}, err
+	}
+
+	return exported("./" + filepath.ToSlash(dir))
+}
+
+// exported runs the main package and reads the catalog it exports.
+func exported(pkg string) (catalog.Catalog, error) {
+	output, err := command("go", "run", pkg)
+	if err != nil {
+		return catalog.Catalog{}, err
+	}
+
+	c, err := catalog.Read(bytes.NewReader(output))
+	if err != nil {
+		return c, fmt.Errorf("%s did not export a catalog, does its main call catalog.ExportIfRequested? %w", pkg, err)
+	}
+
+	return c, nil
+}
+
+func command(name string, args ...string) ([]byte, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Env = append(os.Environ(), catalog.Environment+"=-")
+	cmd.Stderr = os.Stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%s %s: %w", name, strings.Join(args, " "), err)
+	}
+
+	return output, nil
+}
@@ -11,6 +11,7 @@ const (
 	PropertyContext    = property.Context
 	PropertyPayload    = property.Payload
 	PropertyUnderlying = property.Underlying
+	PropertyOperation  = property.Operation
 )
 
 var (
@@ -23,6 +24,7 @@ var (
 var (
 	ModifierTransparent    = modifier.ClassModifierTransparent
 	ModifierOmitStackTrace = modifier.ClassModifierOmitStackTrace
+	ModifierGoroutine      = modifier.ClassModifierGoroutine
 	ModifierNone           = modifier.None
 )
 
@@ -76,7 +78,7 @@ var (
 	ConcurrentUpdate = CommonErrors.Class("concurrent_update")
 
 	// TimeoutElapsed is a class for timeout error
-	TimeoutElapsed = CommonErrors.Class("timeout", trait.Timeout)
+	TimeoutElapsed = CommonErrors.Class("timeout", WithTraits(trait.Timeout))
 
 	// NotImplemented is an error class for lacking implementation
 	NotImplemented = UnsupportedOperation.Class("not_implemented")
@@ -96,7 +98,7 @@ var (
 	transparentWrapper = synthetic.Class("decorate").Apply(modifier.ClassModifierTransparent)
 
 	// Private error class used as a densely opaque wrapper which hides both the original error and its own class
-	_ = synthetic.Class("wrap")
+	opaqueWrapper = synthetic.Class("wrap")
 
 	// Private error class used for stack trace capture
 	stackTraceWrapper = synthetic.Class("stacktrace").Apply(modifier.ClassModifierTransparent)
@@ -0,0 +1,198 @@
+package failure
+
+import (
+	"context"
+	"maps"
+	"strings"
+
+	"github.com/avila-r/failure/modifier"
+	"github.com/avila-r/failure/tags"
+)
+
+type contextKey struct{}
+
+// contextValues is the error metadata carried by a context.Context.
+type contextValues struct {
+	trace  string
+	span   string
+	domain string
+	tags   tags.Tags
+}
+
+func valuesOf(c context.Context) contextValues {
+	if c == nil {
+		return contextValues{}
+	}
+
+	values, _ := c.Value(contextKey{}).(contextValues)
+	return values
+}
+
+func contextWith(c context.Context, update func(*contextValues)) context.Context {
+	values := valuesOf(c)
+	update(&values)
+	return context.WithValue(c, contextKey{}, values)
+}
+
+func ContextWithTrace(c context.Context, trace string) context.Context {
+	return contextWith(c, func(v *contextValues) {
+		v.trace = trace
+	})
+}
+
+func ContextWithSpan(c context.Context, span string) context.Context {
+	return contextWith(c, func(v *contextValues) {
+		v.span = span
+	})
+}
+
+func ContextWithDomain(c context.Context, domain string) context.Context {
+	return contextWith(c, func(v *contextValues) {
+		v.domain = domain
+	})
+}
+
+// ContextWithTags adds tags to the ones already stored in c, replacing those with the same key.
+func ContextWithTags(c context.Context, t tags.Tags) context.Context {
+	return contextWith(c, func(v *contextValues) {
+		merged := maps.Clone(v.tags)
+		if merged == nil {
+			merged = tags.Tags{}
+		}
+		maps.Copy(merged, t)
+		v.tags = merged
+	})
+}
+
+// ContextWithTraceparent stores the trace and span of a W3C traceparent header.
+func ContextWithTraceparent(c context.Context, header string) (context.Context, error) {
+	trace, span, err := ParseTraceparent(header)
+	if err != nil {
+		return c, err
+	}
+
+	return contextWith(c, func(v *contextValues) {
+		v.trace, v.span = trace, span
+	}), nil
+}
+
+// ParseTraceparent extracts trace and span identifiers from a W3C traceparent header,
+// see https://www.w3.org/TR/trace-context/#traceparent-header
+func ParseTraceparent(header string) (trace, span string, err error) {
+	header = strings.TrimSpace(header)
+
+	invalid := func(reason string) (string, string, error) {
+		return "", "", IllegalFormat.New("invalid traceparent %q: %s", header, reason)
+	}
+
+	if len(header) < 55 {
+		return invalid("too short")
+	}
+
+	version := header[0:2]
+	switch {
+	case !hexadecimal(version) || version == "ff":
+		return invalid("bad version")
+	case version == "00" && len(header) != 55:
+		return invalid("unexpected data after flags")
+	case len(header) > 55 && header[55] != '-':
+		return invalid("bad delimiter")
+	case header[2] != '-' || header[35] != '-' || header[52] != '-':
+		return invalid("bad delimiter")
+	}
+
+	trace, span = header[3:35], header[36:52]
+	switch {
+	case !hexadecimal(trace) || trace == strings.Repeat("0", 32):
+		return invalid("bad trace id")
+	case !hexadecimal(span) || span == strings.Repeat("0", 16):
+		return invalid("bad parent id")
+	case !hexadecimal(header[53:55]):
+		return invalid("bad flags")
+	}
+
+	return trace, span, nil
+}
+
+func hexadecimal(s string) bool {
+	for _, r := range s {
+		if !('0' <= r && r <= '9' || 'a' <= r && r <= 'f') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+// FromContext returns a builder of the default class pre-populated
+// with the metadata stored in c.
+func FromContext(c context.Context) ErrorBuilder {
+	return Builder(DefaultClass).Within(c)
+}
+
+// Within fills trace, span, domain and tags from the metadata stored in c,
+// keeping the values already set on the builder. For classes with ModifierGoroutine,
+// pprof labels of c are recorded as well.
+func (b ErrorBuilder) Within(c context.Context) ErrorBuilder {
+	values := valuesOf(c)
+
+	if b.trace == "" {
+		b.trace = values.trace
+	}
+
+	if b.span == "" {
+		b.span = values.span
+	}
+
+	if b.domain == "" {
+		b.domain = values.domain
+	}
+
+	if len(values.tags) > 0 {
+		merged := maps.Clone(values.tags)
+		maps.Copy(merged, b.tags)
+		b.tags = merged
+	}
+
+	if modifier.RecordGoroutine(b.class.Modifiers) {
+		b.labels = labels(c)
+	}
+
+	return b
+}
+
+// Within fills trace, span, domain and tags from the metadata stored in c,
+// keeping the values already set on the error. For classes with ModifierGoroutine,
+// pprof labels of c are recorded as well.
+func (e *Error) Within(c context.Context) *Error {
+	values := valuesOf(c)
+	e = e.mutable()
+
+	if e.trace == "" {
+		e.trace = values.trace
+	}
+
+	if e.span == "" {
+		e.span = values.span
+	}
+
+	if e.domain == "" {
+		e.domain = values.domain
+	}
+
+	if len(values.tags) > 0 {
+		merged := maps.Clone(values.tags)
+		maps.Copy(merged, e.tags)
+		e.tags = merged
+	}
+
+	if modifier.RecordGoroutine(e.class.Modifiers) {
+		e.labels = labels(c)
+	}
+
+	return e
+}
+
+func (c *ErrorChain) Within(context context.Context) *ErrorChain {
+	c.err = c.err.Within(context)
+	return c
+}
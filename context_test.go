@@ -0,0 +1,36 @@
+package failure_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/avila-r/failure"
+)
+
+func TestWithinKeepsSpanWithoutTrace(t *testing.T) {
+	c := failure.ContextWithTrace(context.Background(), "trace-from-context")
+	c = failure.ContextWithSpan(c, "span-from-context")
+
+	for name, err := range map[string]*failure.Error{
+		"Builder": failure.Builder(failure.DataUnavailable).Span("own-span").Within(c).Build(),
+		"Error":   failure.DataUnavailable.New("missing").WithSpan("own-span").Within(c),
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err.Span() != "own-span" {
+				t.Fatalf("span overwritten by the context: %q", err.Span())
+			}
+			if err.Trace() != "trace-from-context" {
+				t.Fatalf("trace not filled from the context: %q", err.Trace())
+			}
+		})
+	}
+}
+
+func TestWithinFillsSpanOnly(t *testing.T) {
+	c := failure.ContextWithSpan(context.Background(), "span-from-context")
+
+	err := failure.DataUnavailable.New("missing").WithTrace("own-trace").Within(c)
+	if err.Trace() != "own-trace" || err.Span() != "span-from-context" {
+		t.Fatalf("unexpected trace %q and span %q", err.Trace(), err.Span())
+	}
+}
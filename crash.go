@@ -0,0 +1,158 @@
+package failure
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/avila-r/failure/report"
+)
+
+// CrashConfig configures CrashHandler.
+type CrashConfig struct {
+	// Dir is the directory reports are written to, os.TempDir() when empty
+	Dir string
+	// Exit is the code the process exits with once reports are written,
+	// the panic is raised again when zero
+	Exit int
+	// Output receives the location of the written reports, os.Stderr when nil
+	Output io.Writer
+}
+
+// Crash is the structured report written by CrashHandler.
+type Crash struct {
+	Time        time.Time         `json:"time"`
+	Panic       string            `json:"panic"`
+	Error       *Error            `json:"error,omitempty"`
+	Breadcrumbs []BreadcrumbEntry `json:"breadcrumbs,omitempty"`
+	Report      report.Metadata   `json:"report"`
+	Goroutines  string            `json:"goroutines"`
+}
+
+// CrashHandler writes a crash report for an unrecovered panic, as crash-<time>-<pid>.json
+// and crash-<time>-<pid>.txt in the configured directory, then exits or panics again.
+// It must be deferred directly by main, or by the function starting a goroutine:
+//
+//	func main() {
+//		defer failure.CrashHandler(failure.CrashConfig{Dir: "/var/crash", Exit: 2})
+//		// ...
+//	}
+func CrashHandler(config CrashConfig) {
+	r := recover()
+	if r == nil {
+		return
+	}
+
+	// the process is going down: a schema violation must not prevent the report
+	SetSchemaEnforcement(EnforceIgnore)
+
+	crash := Crash{
+		Time:        now(),
+		Panic:       fmt.Sprint(r),
+		Breadcrumbs: Breadcrumbs(),
+		Report:      report.Get(),
+		Goroutines:  goroutines(),
+	}
+	if err, ok := r.(error); ok {
+		crash.Error = locate(err)
+	}
+
+	output := config.Output
+	if output == nil {
+		output = os.Stderr
+	}
+
+	paths, err := crash.write(config.Dir)
+	for _, path := range paths {
+		_, _ = fmt.Fprintf(output, "failure: crash report written to %s\n", path)
+	}
+	if err != nil {
+		_, _ = fmt.Fprintf(output, "failure: unable to write crash report: %v\n", err)
+	}
+
+	if config.Exit != 0 {
+		os.Exit(config.Exit)
+	}
+
+	panic(r)
+}
+
+// write stores the textual and the JSON reports in dir, independently of each other,
+// and returns the paths of those written.
+func (c Crash) write(dir string) ([]string, error) {
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	var (
+		base  = filepath.Join(dir, fmt.Sprintf("crash-%s-%d", c.Time.UTC().Format("20060102T150405.000000000Z"), os.Getpid()))
+		paths = []string{}
+		errs  = []error{}
+		files = []struct {
+			path   string
+			render func() ([]byte, error)
+		}{
+			{base + ".txt", func() ([]byte, error) { return []byte(c.String()), nil }},
+			{base + ".json", func() ([]byte, error) { return json.MarshalIndent(c, "", "  ") }},
+		}
+	)
+
+	for _, file := range files {
+		content, err := file.render()
+		if err == nil {
+			err = os.WriteFile(file.path, content, 0o644)
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", file.path, err))
+			continue
+		}
+		paths = append(paths, file.path)
+	}
+
+	return paths, errors.Join(errs...)
+}
+
+func (c Crash) String() string {
+	text := strings.Builder{}
+
+	fmt.Fprintf(&text, "panic: %s\ntime: %s\n", c.Panic, c.Time.Format(time.RFC3339Nano))
+
+	if c.Error != nil {
+		fmt.Fprintf(&text, "\nerror:\n%+v\n", c.Error)
+	}
+
+	if len(c.Breadcrumbs) > 0 {
+		text.WriteString("\nbreadcrumbs:\n")
+		for _, breadcrumb := range c.Breadcrumbs {
+			fmt.Fprintf(&text, " %s\n", breadcrumb)
+		}
+	}
+
+	fmt.Fprintf(&text, "\nreport: %s\n", c.Report)
+	fmt.Fprintf(&text, "\ngoroutines:\n%s", c.Goroutines)
+
+	return text.String()
+}
+
+// goroutines returns the stacks of every goroutine, growing the buffer until they fit.
+func goroutines() string {
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			return string(buf[:n])
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+}
@@ -1,33 +1,203 @@
 package ctx
 
-import "reflect"
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
 
 type Context map[string]any
 
+// Provider is a context value computed only when the context is evaluated.
+type Provider interface {
+	Provide() any
+}
+
+// Evaluated replaces every value of ctx by its evaluation, see Evaluate.
 func Evaluated(ctx Context) Context {
-	for key, value := range ctx {
-		switch v := value.(type) {
-		case map[string]any:
-			ctx[key] = Evaluated(v)
-		default:
-			val := reflect.ValueOf(value)
-			if !val.IsValid() || val.Kind() != reflect.Func {
-				ctx[key] = value
-			} else if val.Type().NumIn() != 0 || val.Type().NumOut() != 1 {
-				ctx[key] = value
+	for key, value := range Evaluate(ctx) {
+		ctx[key] = value
+	}
+
+	return ctx
+}
+
+// Evaluate returns a copy of ctx where providers and functions without parameters
+// are replaced by their result, pointers by the value they point to, and nested maps
+// by their evaluation. Functions with parameters are replaced by their type name,
+// panics by a description of the panic and cyclic references, including the ones
+// reached through struct fields, slices and arrays, by a placeholder.
+// ctx itself is never modified.
+func Evaluate(ctx Context) Context {
+	if ctx == nil {
+		return nil
+	}
+
+	return resolve(reflect.ValueOf(ctx), map[uintptr]struct{}{}).(Context)
+}
+
+func resolve(val reflect.Value, visiting map[uintptr]struct{}) any {
+	if !val.IsValid() {
+		return nil
+	}
+
+	if val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return nil
+		}
+		return resolve(val.Elem(), visiting)
+	}
+
+	if val.CanInterface() {
+		if provider, ok := val.Interface().(Provider); ok && !(val.Kind() == reflect.Pointer && val.IsNil()) {
+			return resolve(call(func() any {
+				return provider.Provide()
+			}), visiting)
+		}
+	}
+
+	switch val.Kind() {
+	case reflect.Func:
+		if val.IsNil() {
+			return nil
+		}
+
+		if val.Type().NumIn() != 0 || val.Type().NumOut() != 1 {
+			return val.Type().String()
+		}
+
+		return resolve(call(func() any {
+			return val.Call(nil)[0].Interface()
+		}), visiting)
+
+	case reflect.Pointer:
+		if val.IsNil() {
+			return nil
+		}
+
+		address := val.Pointer()
+		if _, ok := visiting[address]; ok {
+			return cycle
+		}
+		visiting[address] = struct{}{}
+		defer delete(visiting, address)
+
+		return resolve(val.Elem(), visiting)
+
+	case reflect.Map:
+		if val.IsNil() {
+			return val.Interface()
+		}
+
+		if val.Type().Key().Kind() != reflect.String {
+			if cyclic(val, visiting) {
+				return cycle
 			}
+			return val.Interface()
+		}
 
-			ctx[key] = val.Call([]reflect.Value{})[0].Interface()
+		address := val.Pointer()
+		if _, ok := visiting[address]; ok {
+			return cycle
 		}
+		visiting[address] = struct{}{}
+		defer delete(visiting, address)
+
+		result := make(map[string]any, val.Len())
+		for iter := val.MapRange(); iter.Next(); {
+			result[iter.Key().String()] = resolve(iter.Value(), visiting)
+		}
+
+		if val.Type() == reflect.TypeFor[Context]() {
+			return Context(result)
+		}
+		return result
+
+	default:
+		if !val.CanInterface() {
+			return nil
+		}
+		if cyclic(val, visiting) {
+			return cycle
+		}
+		return val.Interface()
+	}
+}
+
+const cycle = "<cycle>"
+
+// cyclic reports whether val refers back to itself or to one of the values being visited,
+// following pointers, maps, slices, arrays and the exported fields of structs
+// the way encoding/json does.
+func cyclic(val reflect.Value, visiting map[uintptr]struct{}) bool {
+	if !val.IsValid() {
+		return false
 	}
 
-	for key, value := range ctx {
-		val := reflect.ValueOf(value)
-		for val.Kind() == reflect.Ptr && !val.IsNil() {
-			val = val.Elem()
+	if val.CanInterface() {
+		if _, ok := val.Interface().(json.Marshaler); ok {
+			return false
 		}
-		ctx[key] = val.Interface()
 	}
 
-	return ctx
+	switch val.Kind() {
+	case reflect.Interface:
+		return !val.IsNil() && cyclic(val.Elem(), visiting)
+
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if val.IsNil() || (val.Kind() == reflect.Slice && val.Len() == 0) {
+			return false
+		}
+
+		address := val.Pointer()
+		if _, ok := visiting[address]; ok {
+			return true
+		}
+		visiting[address] = struct{}{}
+		defer delete(visiting, address)
+
+		switch val.Kind() {
+		case reflect.Pointer:
+			return cyclic(val.Elem(), visiting)
+		case reflect.Map:
+			for iter := val.MapRange(); iter.Next(); {
+				if cyclic(iter.Value(), visiting) {
+					return true
+				}
+			}
+			return false
+		}
+
+		fallthrough
+
+	case reflect.Array:
+		for i := range val.Len() {
+			if cyclic(val.Index(i), visiting) {
+				return true
+			}
+		}
+		return false
+
+	case reflect.Struct:
+		for i := range val.NumField() {
+			if field := val.Type().Field(i); (field.IsExported() || field.Anonymous) && cyclic(val.Field(i), visiting) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
+
+// call isolates a panic raised by f, returning its description instead.
+func call(f func() any) (result reflect.Value) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = reflect.ValueOf(fmt.Sprintf("<panic: %v>", r))
+		}
+	}()
+
+	return reflect.ValueOf(f())
 }
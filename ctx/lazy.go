@@ -0,0 +1,45 @@
+package ctx
+
+import (
+	"fmt"
+	"sync"
+)
+
+var _ Provider = (*Lazy[any])(nil)
+
+// Lazy is a typed provider evaluated at most once, on first use.
+// A panic raised by its function is recovered and reported by Get.
+type Lazy[T any] struct {
+	once  sync.Once
+	fn    func() T
+	value T
+	err   error
+}
+
+func Lazily[T any](fn func() T) *Lazy[T] {
+	return &Lazy[T]{fn: fn}
+}
+
+func (l *Lazy[T]) Get() (T, error) {
+	l.once.Do(func() {
+		defer func() {
+			if r := recover(); r != nil {
+				l.err = fmt.Errorf("lazy context value panicked: %v", r)
+			}
+		}()
+
+		l.value = l.fn()
+	})
+
+	return l.value, l.err
+}
+
+// Provide implements Provider.
+func (l *Lazy[T]) Provide() any {
+	value, err := l.Get()
+	if err != nil {
+		return "<" + err.Error() + ">"
+	}
+
+	return value
+}
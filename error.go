@@ -1,17 +1,17 @@
 package failure
 
 import (
-	"errors"
 	"fmt"
 	"io"
 	"log/slog"
-	"math/rand"
 	"reflect"
 	"strings"
 	"time"
 
 	"github.com/avila-r/failure/ctx"
 	"github.com/avila-r/failure/property"
+	"github.com/avila-r/failure/report"
+	"github.com/avila-r/failure/severity"
 	"github.com/avila-r/failure/stacktrace"
 	"github.com/avila-r/failure/tags"
 	"github.com/avila-r/failure/trail"
@@ -25,17 +25,22 @@ var (
 )
 
 type Error struct {
+	id    string
 	class *ErrorClass
 
 	message string
 	cause   error
 
 	stacktrace    *stacktrace.StackTrace
+	returns       []uintptr
 	properties    *property.List
 	transparent   bool
+	translucent   bool
+	sealed        bool
 	hasUnderlying bool
 	ppc           uint8
 
+	created  time.Time
 	time     time.Time
 	duration time.Duration
 
@@ -46,11 +51,22 @@ type Error struct {
 	trace string
 	span  string
 
-	hint   string
-	public string
-	owner  string
+	goroutine uint64
+	labels    map[string]string
+
+	hint     string
+	public   string
+	owner    string
+	severity severity.Severity
 
 	trail *trail.Trail
+
+	// frozen errors are never mutated, enrichment operates on a fresh copy instead
+	frozen bool
+	// origin is the frozen error this one was copied from
+	origin *Error
+	// template is the template this error was produced from
+	template *ErrorTemplate
 }
 
 // Error implements the error interface.
@@ -65,7 +81,7 @@ func (e *Error) Error() string {
 //
 //	%s		simple message output
 //	%v		simple message output
-//	%+v		full output complete with a stack trace
+//	%+v		full output complete with a stack trace, and the cause of sealed errors
 //
 // In is nearly always preferable to use %+v format.
 // If a stack trace is not required, it should be omitted
@@ -73,9 +89,18 @@ func (e *Error) Error() string {
 func (e *Error) Format(state fmt.State, verb rune) {
 	switch message := e.Summary(); verb {
 	case 'v':
+		if state.Flag('+') {
+			e.audit()
+			message = e.summary(true)
+		}
 		_, _ = io.WriteString(state, message)
-		if state.Flag('+') && e.stacktrace != nil {
-			e.stacktrace.Format(state, verb)
+		if state.Flag('+') {
+			if e.stacktrace != nil {
+				e.stacktrace.Format(state, verb)
+			}
+			e.formatGoroutine(state)
+			e.formatReturns(state)
+			e.formatDurations(state)
 		}
 	case 's':
 		_, _ = io.WriteString(state, message)
@@ -86,30 +111,102 @@ func (e *Error) LogValue() slog.Value {
 	return e.Logs()
 }
 
+// Class returns the class of the first opaque error of the chain.
+// Foreign causes of transparent wrappers are classified, see Classify.
 func (e *Error) Class() *ErrorClass {
+	return e.classification().class
+}
+
+func (e *Error) classification() classification {
+	visited := map[*Error]struct{}{}
+
 	cause := e
-	for cause != nil {
+	for {
 		if !cause.transparent {
-			return cause.class
+			return classification{class: cause.class}
 		}
 
-		cause = Cast(cause.cause)
+		if _, ok := visited[cause]; ok {
+			return classification{class: foreignClass}
+		}
+		visited[cause] = struct{}{}
+
+		next := locate(cause.cause)
+		if next == nil {
+			return classify(cause.cause)
+		}
+		cause = next
 	}
+}
+
+func (e *Error) Description() string {
+	return e.Class().Description
+}
+
+func (e *Error) Docs() string {
+	return e.Class().Docs
+}
 
-	return foreignClass
+func (e *Error) Code() string {
+	return e.Class().Code
+}
+
+// Status returns the HTTP status set through the status code property,
+// falling back to the default status of the class.
+func (e *Error) Status() int {
+	if code, ok := e.Property(property.StatusCode).Value.(int); ok {
+		return code
+	}
+
+	return e.Class().Status
+}
+
+// ID returns the unique identifier generated when the error was built.
+// It identifies the occurrence rather than a value: copies returned by enrichment
+// keep the ID of the error they were derived from, only copies of a sentinel,
+// being new occurrences, are given a fresh one.
+func (e *Error) ID() string {
+	return e.id
 }
 
 func (e *Error) Message() string {
 	return e.message
 }
 
+// Cause returns the cause of the error, or nil when it is sealed, see Wrap.
 func (e *Error) Cause() error {
+	if e.sealed {
+		return nil
+	}
+
 	return e.cause
 }
 
+// Reveal returns the cause hidden by a sealed error, see Wrap.
+// It is meant for privileged code, such as logging or auditing.
+func (e *Error) Reveal() error {
+	if e.sealed {
+		return e.cause
+	}
+
+	return nil
+}
+
+func (e *Error) Sealed() bool {
+	return e.sealed
+}
+
+// Time returns the time explicitly set through WithTime,
+// falling back to the creation time of the innermost error.
 func (e *Error) Time() time.Time {
-	return Deep(e, func(e *Error) time.Time {
+	if t := Deep(e, func(e *Error) time.Time {
 		return e.time
+	}); !t.IsZero() {
+		return t
+	}
+
+	return Deep(e, func(e *Error) time.Time {
+		return e.created
 	})
 }
 
@@ -138,7 +235,7 @@ func (e *Error) Context() ctx.Context {
 		return e.context
 	})
 
-	return ctx.Evaluated(context)
+	return ctx.Evaluate(context)
 }
 
 func (e *Error) Trace() string {
@@ -150,7 +247,9 @@ func (e *Error) Trace() string {
 		return trace
 	}
 
-	return fmt.Sprintf("%d-%d", time.Now().UnixNano(), rand.Int63())
+	return Deep(e, func(e *Error) string {
+		return e.id
+	})
 }
 
 func (e *Error) Hint() string {
@@ -179,7 +278,7 @@ func (e *Error) Trail() string {
 	blocks := []string{}
 	topFrame := ""
 
-	Recurse(e, func(e *Error) {
+	traverse(e, func(e *Error) {
 		if e.trail != nil && len(e.trail.Frames) > 0 {
 			err := ""
 			if e.cause != nil {
@@ -209,7 +308,7 @@ func (e *Error) Trail() string {
 
 func (o *Error) Sources() string {
 	blocks := [][]string{}
-	Recurse(o, func(e *Error) {
+	traverse(o, func(e *Error) {
 		if e.trail != nil && len(e.trail.Frames) > 0 {
 			header, body := e.trail.Source()
 
@@ -243,56 +342,94 @@ func (o *Error) Sources() string {
 }
 
 func (e *Error) WithOwner(owner string) *Error {
+	e = e.mutable()
 	e.owner = owner
 	return e
 }
 
 func (e *Error) WithPublic(public string) *Error {
+	e = e.mutable()
 	e.public = public
 	return e
 }
 
 func (e *Error) WithHint(hint string) *Error {
+	e = e.mutable()
 	e.hint = hint
 	return e
 }
 
 func (e *Error) WithSpan(span string) *Error {
+	e = e.mutable()
 	e.span = span
 	return e
 }
 
 func (e *Error) WithTrace(trace string) *Error {
+	e = e.mutable()
 	e.trace = trace
 	return e
 }
 
 func (e *Error) WithTags(tags tags.Tags) *Error {
+	e = e.mutable()
 	e.tags = tags
 	return e
 }
 
+// WithContext sets structured values describing the circumstances of the error.
+// Values implementing ctx.Provider, such as ctx.Lazy, are evaluated when read.
+func (e *Error) WithContext(context ctx.Context) *Error {
+	e = e.mutable()
+	e.context = context
+	return e
+}
+
 func (e *Error) WithDomain(domain string) *Error {
+	e = e.mutable()
 	e.domain = domain
 	return e
 }
 
 func (e *Error) WithDuration(duration time.Duration) *Error {
+	e = e.mutable()
 	e.duration = duration
 	return e
 }
 
+// WithDurationSince sets the duration to the time elapsed since t, or to zero when t lies in the future.
 func (e *Error) WithDurationSince(t time.Time) *Error {
-	e.duration = time.Since(t)
+	e = e.mutable()
+	e.duration = elapsed(t)
 	return e
 }
 
 func (e *Error) WithTime(time time.Time) *Error {
+	e = e.mutable()
 	e.time = time
 	return e
 }
 
+// WithCause replaces the cause of the error, panicking if err already
+// holds the error, as that would make the chain endless.
 func (e *Error) WithCause(err error) *Error {
+	e = e.mutable()
+
+	if err != nil {
+		found := false
+		for _, casted := range nearest(err) {
+			traverse(casted, func(other *Error) {
+				found = found || other == e
+			})
+		}
+
+		if found {
+			IllegalState.
+				New("cause cycle: %q can't be caused by an error holding it", e.message).
+				Panic()
+		}
+	}
+
 	e.cause = err
 	return e
 }
@@ -335,16 +472,51 @@ func (e *Error) Recover(f func()) (err error) {
 }
 
 func (e *Error) Chain() ErrorChain {
-	return ErrorChain{e}
+	return ErrorChain{e.mutable()}
+}
+
+// Freeze turns e into a sentinel: it will never be mutated again,
+// and enriching it returns a fresh copy which still matches e through Is.
+// A sentinel is matched by identity only, errors with the same message don't match it.
+// Freeze isn't synchronized, so it must be called before e is shared,
+// typically at package initialization as Sentinel does.
+func (e *Error) Freeze() *Error {
+	e.frozen = true
+	return e
+}
+
+func (e *Error) Frozen() bool {
+	return e.frozen
+}
+
+// mutable returns e itself, or a fresh copy of it when e is frozen.
+func (e *Error) mutable() *Error {
+	if !e.frozen {
+		return e
+	}
+
+	return e.clone()
+}
+
+func (e *Error) clone() *Error {
+	copy := *e
+	if e.frozen {
+		copy.frozen, copy.origin = false, e
+		copy.created = now()
+		copy.id = generate(copy.created)
+	}
+	return &copy
 }
 
 func (e *Error) Decorate() {
+	e.immutable("Decorate")
 	e.stacktrace = BuilderFrom(e).
 		StackTrace().
 		SetupStackTrace(4)
 }
 
 func (e *Error) Enhance() {
+	e.immutable("Enhance")
 	if e.Cause() != nil {
 		e.stacktrace = BuilderFrom(e).
 			EnhanceStackTrace().
@@ -353,6 +525,7 @@ func (e *Error) Enhance() {
 }
 
 func (e *Error) Decorated() *Error {
+	e = e.mutable()
 	e.stacktrace = BuilderFrom(e).
 		StackTrace().
 		SetupStackTrace(4)
@@ -361,6 +534,7 @@ func (e *Error) Decorated() *Error {
 }
 
 func (e *Error) Enhanced() *Error {
+	e = e.mutable()
 	if e.Cause() != nil {
 		e.stacktrace = BuilderFrom(e).
 			EnhanceStackTrace().
@@ -370,6 +544,15 @@ func (e *Error) Enhanced() *Error {
 	return e
 }
 
+// immutable panics for frozen errors, as method can't hand a copy back.
+func (e *Error) immutable(method string) {
+	if e.frozen {
+		IllegalState.
+			New("%s can't be called on a frozen error, use %sd instead", method, method).
+			Panic()
+	}
+}
+
 func (e *Error) Belongs(err error) bool {
 	typed := Cast(err)
 
@@ -377,7 +560,14 @@ func (e *Error) Belongs(err error) bool {
 }
 
 func (e *Error) Is(err error) bool {
+	if t, ok := err.(*ErrorTemplate); ok {
+		return e.template != nil && e.template == t
+	}
+
 	if err, ok := err.(*Error); ok {
+		if err.frozen {
+			return e == err || e.origin == err
+		}
 		return e.message == err.message
 	}
 
@@ -396,41 +586,14 @@ func (e *Error) As(target any) bool {
 	return false
 }
 
+// Has reports whether the class of the error has trait, or, for translucent errors, whether their cause has it.
 func (e *Error) Has(trait trait.Trait) bool {
-	cause := e
-	for cause != nil {
-		if !cause.transparent {
-			return cause.class.Has(trait)
-		}
-		cause = Cast(cause.cause)
-	}
-
-	return false
+	return e.classification().Has(trait) || (e.translucent && Has(e.cause, trait))
 }
 
+// Extends reports whether the class of the error extends c, or, for translucent errors, whether their cause does.
 func (e *Error) Extends(c *ErrorClass) bool {
-	cause := e
-	for cause != nil {
-		if !cause.transparent {
-			return cause.Class().Is(c)
-		}
-
-		cause = func() *Error {
-			raw := e.cause
-			for raw != nil {
-				typed := Cast(raw)
-				if typed != nil {
-					return typed
-				}
-
-				raw = errors.Unwrap(raw)
-			}
-
-			return nil
-		}()
-	}
-
-	return false
+	return e.Class().Is(c) || (e.translucent && Extends(e.cause, c))
 }
 
 func (e *Error) Attribute(key string) property.Result {
@@ -445,37 +608,44 @@ func (e *Error) Value(key string) property.Result {
 	return e.Property(key)
 }
 
+// Property looks key up on the error and, through transparent and translucent errors, on its causes.
 func (e *Error) Property(key string) property.Result {
-	cause := e
-	for cause != nil {
-		value, ok := cause.properties.Get(key)
-		if ok {
-			return property.Result{
-				Value: value,
-				Ok:    true,
-			}
-		}
+	return e.property(key, map[*Error]struct{}{})
+}
 
-		if !cause.transparent {
-			break
+func (e *Error) property(key string, visited map[*Error]struct{}) property.Result {
+	if _, ok := visited[e]; ok {
+		return property.Empty()
+	}
+	visited[e] = struct{}{}
+
+	if value, ok := e.properties.Get(key); ok {
+		return property.Result{
+			Value: value,
+			Ok:    true,
 		}
+	}
 
-		cause = Cast(cause.cause)
+	if !e.transparent && !e.translucent {
+		return property.Empty()
 	}
 
-	return property.Result{
-		Value: nil,
-		Ok:    false,
+	for _, next := range nearest(e.cause) {
+		if result := next.property(key, visited); result.Ok {
+			return result
+		}
 	}
+
+	return property.Empty()
 }
 
 func (e *Error) With(key string, value any) *Error {
-	copy := *e
+	copy := e.clone()
 	copy.properties = copy.properties.Set(key, value)
 	if copy.ppc < 255 {
 		copy.ppc++
 	}
-	return &copy
+	return copy.conformProperty(key, value)
 }
 
 func (e *Error) Panic() {
@@ -510,14 +680,20 @@ func (e *Error) Also(errs ...error) *Error {
 }
 
 func (e *Error) Unwrap() error {
-	if e != nil && e.cause != nil && e.transparent {
+	if e != nil && e.cause != nil && (e.transparent || e.translucent) {
 		return e.cause
 	} else {
 		return nil
 	}
 }
 
+// Summary returns the class, message, properties and cause of the error.
+// Causes of sealed errors are omitted.
 func (e *Error) Summary() string {
+	return e.summary(false)
+}
+
+func (e *Error) summary(reveal bool) string {
 	var join = func(delimiter string, parts ...string) string {
 		switch len(parts) {
 		case 0:
@@ -563,7 +739,7 @@ func (e *Error) Summary() string {
 	}
 
 	text := join(" ", e.message, properties)
-	if cause := e.cause; cause != nil {
+	if cause := e.cause; cause != nil && (!e.sealed || reveal) {
 		text = join(", cause: ", text, cause.Error())
 	}
 
@@ -576,7 +752,7 @@ func (e *Error) Summary() string {
 		underlying = fmt.Sprintf("(hidden: %s)", join(", ", details...))
 	}
 
-	if transparent := join(" ", text, underlying); e.transparent {
+	if transparent := join(" ", text, underlying); e.transparent || e.class == opaqueWrapper {
 		return transparent
 	} else {
 		return join(": ", e.class.Name, transparent)
@@ -592,8 +768,22 @@ func (e *Error) Underlying() []error {
 }
 
 func (e *Error) Logs() slog.Value {
+	e.audit()
+
 	attrs := []slog.Attr{slog.String("message", e.message)}
 
+	if e.id != "" {
+		attrs = append(attrs, slog.String("id", e.id))
+	}
+
+	if code := e.Code(); code != "" {
+		attrs = append(attrs, slog.String("code", code))
+	}
+
+	if s := e.Severity(); s != severity.Unset {
+		attrs = append(attrs, slog.String("severity", s.String()))
+	}
+
 	if err := e.Error(); err != "" {
 		attrs = append(attrs, slog.String("err", err))
 	}
@@ -630,6 +820,18 @@ func (e *Error) Logs() slog.Value {
 		attrs = append(attrs, slog.String("owner", owner))
 	}
 
+	if e.cause != nil {
+		attrs = append(attrs, slog.String("cause", e.cause.Error()))
+	}
+
+	if goroutine := e.Goroutine(); goroutine != 0 {
+		attrs = append(attrs, slog.Uint64("goroutine", goroutine))
+	}
+
+	if labels := e.Labels(); len(labels) > 0 {
+		attrs = append(attrs, slog.Any("labels", labels))
+	}
+
 	if context := e.Context(); len(context) > 0 {
 		attrs = append(attrs,
 			slog.Group(
@@ -657,5 +859,9 @@ func (e *Error) Logs() slog.Value {
 		attrs = append(attrs, slog.String("stacktrace", trail))
 	}
 
+	if report.Enabled() {
+		attrs = append(attrs, slog.Any("report", report.Get()))
+	}
+
 	return slog.GroupValue(attrs...)
 }
@@ -29,6 +29,12 @@ func New(message string, v ...any) *Error {
 		Build()
 }
 
+// Sentinel creates a frozen error, meant to be declared at package level
+// and safely enriched from concurrent code.
+func Sentinel(message string, v ...any) *Error {
+	return New(message, v...).Freeze()
+}
+
 func Blank() *Error {
 	return Builder(DefaultClass).
 		Build()
@@ -54,6 +60,34 @@ func Decorate(err error, message string, v ...any) *Error {
 		Build()
 }
 
+// Wrap creates an error of class, nil meaning no class at all, sealing err as its cause:
+// the cause is hidden from Is, As, Extends, Has, Property and chain accessors such as Public,
+// Hint or Tags, so that internal errors don't leak across API boundaries,
+// but still appears in %+v, Trail, JSON and logs.
+func Wrap(err error, class *ErrorClass, message string, v ...any) *Error {
+	if class == nil {
+		class = opaqueWrapper
+	}
+
+	return Builder(class).
+		Message(message, v...).
+		Cause(err).
+		Sealed().
+		Build()
+}
+
+// Reveal returns the cause hidden by the outermost sealed error of err, if any.
+func Reveal(err error) error {
+	for err != nil {
+		if casted := Cast(err); casted != nil && casted.sealed {
+			return casted.cause
+		}
+		err = Unwrap(err)
+	}
+
+	return nil
+}
+
 func Decorated(err error) *Error {
 	if casted := Cast(err); casted != nil && casted.stacktrace != nil {
 		return BuilderFrom(casted).
@@ -89,28 +123,19 @@ func Enhanced(err error) *Error {
 }
 
 func Extends(err error, c *ErrorClass) bool {
-	casted := func() *Error {
-		raw := err
-		for raw != nil {
-			typed := Cast(raw)
-			if typed != nil {
-				return typed
-			}
-			raw = errors.Unwrap(raw)
-		}
-
-		return nil
-	}()
+	if casted := locate(err); casted != nil {
+		return casted.Extends(c)
+	}
 
-	return casted != nil && casted.Extends(c)
+	return err != nil && classify(err).class.Is(c)
 }
 
 func Has(err error, trait trait.Trait) bool {
-	if casted := Cast(err); casted != nil {
+	if casted := locate(err); casted != nil {
 		return casted.Has(trait)
 	}
 
-	return false
+	return err != nil && classify(err).Has(trait)
 }
 
 func Is(err, target error) bool {
@@ -179,7 +204,7 @@ func Cause(err error) error {
 }
 
 func Property(err error, key string) property.Result {
-	if err := Cast(err); err != nil {
+	if err := locate(err); err != nil {
 		return err.Property(key)
 	}
 
@@ -187,7 +212,7 @@ func Property(err error, key string) property.Result {
 }
 
 func Extract[T any](err error, key string) (out T) {
-	if err := Cast(err); err != nil {
+	if err := locate(err); err != nil {
 		err.Property(key).Bind(&out)
 	}
 
@@ -195,7 +220,7 @@ func Extract[T any](err error, key string) (out T) {
 }
 
 func Contains(err error, key string) bool {
-	if err := Cast(err); err != nil {
+	if err := locate(err); err != nil {
 		return err.Property(key).Ok
 	}
 
@@ -203,7 +228,7 @@ func Contains(err error, key string) bool {
 }
 
 func Inspect(err error) string {
-	if err := Cast(err); err != nil {
+	if err := locate(err); err != nil {
 		return err.Summary()
 	}
 
@@ -272,62 +297,127 @@ func Trait(label string) trait.Trait {
 	return trait.New(label)
 }
 
+// Deep returns the first non-zero value of getter, starting from the innermost error of the chain.
+// Foreign wrappers between errors are unwrapped, cycles are visited once,
+// and causes hidden by sealed errors are never reached, see Wrap.
 func Deep[T comparable](err *Error, getter func(*Error) T) T {
-	if err.cause == nil {
-		return getter(err)
+	return deep(err, getter, false, map[*Error]struct{}{})
+}
+
+func deep[T comparable](err *Error, getter func(*Error) T, reveal bool, visited map[*Error]struct{}) (zero T) {
+	if _, ok := visited[err]; ok {
+		return zero
 	}
+	visited[err] = struct{}{}
 
-	if casted := Cast(err.cause); casted != nil {
-		return func(values ...T) T {
-			var zero T
-			for _, v := range values {
-				if v != zero {
-					return v
-				}
+	if reveal || !err.sealed {
+		for _, next := range nearest(err.cause) {
+			if v := deep(next, getter, reveal, visited); v != zero {
+				return v
 			}
-			return zero
-		}(Deep(casted, getter), getter(err))
+		}
 	}
 
 	return getter(err)
 }
 
+// Recurse calls do on every error of the chain, starting from the outermost one.
+// Foreign wrappers between errors are unwrapped, cycles are visited once,
+// and causes hidden by sealed errors are never reached, see Wrap.
 func Recurse(err *Error, do func(*Error)) {
+	recurse(err, do, false, map[*Error]struct{}{})
+}
+
+// traverse is Recurse reaching sealed causes as well, for renderings
+// such as %+v and Trail, and for consistency checks.
+func traverse(err *Error, do func(*Error)) {
+	recurse(err, do, true, map[*Error]struct{}{})
+}
+
+func recurse(err *Error, do func(*Error), reveal bool, visited map[*Error]struct{}) {
+	if _, ok := visited[err]; ok {
+		return
+	}
+	visited[err] = struct{}{}
+
 	do(err)
 
-	if err.cause == nil {
+	if err.sealed && !reveal {
 		return
 	}
 
-	if casted := Cast(err.cause); casted != nil {
-		Recurse(casted, do)
+	for _, next := range nearest(err.cause) {
+		recurse(next, do, reveal, visited)
 	}
 }
 
+// Gather merges the maps returned by getter for every error of the chain,
+// values of inner errors taking precedence over the ones of outer errors.
 func Gather(err *Error, getter func(*Error) ctx.Context) ctx.Context {
-	if err.cause == nil {
-		return getter(err)
+	maps := []ctx.Context{}
+	Recurse(err, func(e *Error) {
+		maps = append(maps, getter(e))
+	})
+
+	if len(maps) == 1 {
+		return maps[0]
 	}
 
-	if casted := Cast(err.cause); casted != nil {
-		return func(maps ...ctx.Context) ctx.Context {
-			count := 0
-			for i := range maps {
-				count += len(maps[i])
-			}
+	count := 0
+	for i := range maps {
+		count += len(maps[i])
+	}
+
+	out := make(ctx.Context, count)
+	for i := range maps {
+		for k := range maps[i] {
+			out[k] = maps[i][k]
+		}
+	}
+
+	return out
+}
+
+// maxUnwrap bounds the unwrapping of foreign errors, which may be cyclic
+const maxUnwrap = 128
+
+// nearest returns the closest errors found in err, itself included, unwrapping
+// foreign wrappers through both Unwrap() error and Unwrap() []error.
+func nearest(err error) []*Error {
+	result := []*Error{}
+
+	var walk func(err error, level int)
+	walk = func(err error, level int) {
+		if err == nil || level > maxUnwrap {
+			return
+		}
+
+		if casted := Cast(err); casted != nil {
+			result = append(result, casted)
+			return
+		}
 
-			out := make(ctx.Context, count)
-			for i := range maps {
-				for k := range maps[i] {
-					out[k] = maps[i][k]
-				}
+		switch u := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(u.Unwrap(), level+1)
+		case interface{ Unwrap() []error }:
+			for _, err := range u.Unwrap() {
+				walk(err, level+1)
 			}
+		}
+	}
+
+	walk(err, 0)
+	return result
+}
 
-			return out
-		}(ctx.Context{}, getter(err), Gather(casted, getter))
+// locate returns the closest error found in err, itself included.
+func locate(err error) *Error {
+	if found := nearest(err); len(found) > 0 {
+		return found[0]
 	}
 
-	return getter(err)
+	return nil
 }
 
 func InitializeStackTraceTransformer(subtransformer stacktrace.FilePathTransformer) (stacktrace.FilePathTransformer, error) {
@@ -0,0 +1,85 @@
+package failure
+
+import (
+	"context"
+	"io"
+	"maps"
+	"runtime"
+	"runtime/pprof"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// goroutine returns the ID of the calling goroutine, parsed from the header of its stack.
+func goroutine() uint64 {
+	buf := [64]byte{}
+	header := strings.TrimPrefix(string(buf[:runtime.Stack(buf[:], false)]), "goroutine ")
+
+	end := strings.IndexByte(header, ' ')
+	if end < 0 {
+		return 0
+	}
+
+	id, _ := strconv.ParseUint(header[:end], 10, 64)
+	return id
+}
+
+// labels returns the pprof labels stored in c.
+func labels(c context.Context) map[string]string {
+	if c == nil {
+		return nil
+	}
+
+	var result map[string]string
+	pprof.ForLabels(c, func(key, value string) bool {
+		if result == nil {
+			result = map[string]string{}
+		}
+		result[key] = value
+		return true
+	})
+
+	return result
+}
+
+// Goroutine returns the ID of the goroutine which created the innermost error
+// of a class with ModifierGoroutine, or zero.
+func (e *Error) Goroutine() uint64 {
+	return Deep(e, func(e *Error) uint64 {
+		return e.goroutine
+	})
+}
+
+// Labels returns the pprof labels recorded across the chain, for classes with ModifierGoroutine.
+// Labels of inner errors take precedence.
+func (e *Error) Labels() map[string]string {
+	var result map[string]string
+	Recurse(e, func(e *Error) {
+		if len(e.labels) == 0 {
+			return
+		}
+		if result == nil {
+			result = map[string]string{}
+		}
+		maps.Copy(result, e.labels)
+	})
+
+	return result
+}
+
+func (e *Error) formatGoroutine(w io.Writer) {
+	id, labels := e.Goroutine(), e.Labels()
+	if id == 0 && len(labels) == 0 {
+		return
+	}
+
+	_, _ = io.WriteString(w, "\n in goroutine "+strconv.FormatUint(id, 10))
+	if len(labels) > 0 {
+		pairs := make([]string, 0, len(labels))
+		for _, key := range slices.Sorted(maps.Keys(labels)) {
+			pairs = append(pairs, key+"="+labels[key])
+		}
+		_, _ = io.WriteString(w, " {"+strings.Join(pairs, ", ")+"}")
+	}
+}
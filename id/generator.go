@@ -0,0 +1,73 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// Generator produces unique identifiers for error instances.
+// The creation time of the instance is provided for time-ordered formats.
+type Generator interface {
+	Generate(at time.Time) string
+}
+
+// GeneratorFunc adapts an ordinary function to the Generator interface.
+type GeneratorFunc func(at time.Time) string
+
+// Generate implements Generator.
+func (f GeneratorFunc) Generate(at time.Time) string {
+	return f(at)
+}
+
+var (
+	// Random generates 128-bit random identifiers in hexadecimal
+	Random Generator = GeneratorFunc(func(time.Time) string {
+		b := [16]byte{}
+		_, _ = rand.Read(b[:])
+		return hex.EncodeToString(b[:])
+	})
+
+	// ULID generates lexicographically sortable identifiers, see https://github.com/ulid/spec
+	ULID Generator = GeneratorFunc(func(at time.Time) string {
+		const alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+		b := [16]byte{}
+		binary.BigEndian.PutUint64(b[:8], uint64(at.UnixMilli())<<16)
+		_, _ = rand.Read(b[6:])
+
+		// 128 bits are encoded as 26 characters of 5 bits, the first one holding only 3 bits
+		hi, lo := binary.BigEndian.Uint64(b[:8]), binary.BigEndian.Uint64(b[8:])
+		out := [26]byte{}
+		for i := 25; i >= 0; i-- {
+			out[i] = alphabet[lo&0x1f]
+			lo = lo>>5 | hi<<59
+			hi >>= 5
+		}
+		return string(out[:])
+	})
+
+	// UUIDv7 generates time-ordered UUIDs as described in RFC 9562
+	UUIDv7 Generator = GeneratorFunc(func(at time.Time) string {
+		b := [16]byte{}
+		binary.BigEndian.PutUint64(b[:8], uint64(at.UnixMilli())<<16)
+		_, _ = rand.Read(b[6:])
+
+		b[6] = b[6]&0x0f | 0x70
+		b[8] = b[8]&0x3f | 0x80
+
+		return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+	})
+)
+
+// Sequence generates deterministic identifiers made of prefix and an increasing counter,
+// meant for tests.
+func Sequence(prefix string) Generator {
+	var counter uint64
+	return GeneratorFunc(func(time.Time) string {
+		return fmt.Sprintf("%s%d", prefix, atomic.AddUint64(&counter, 1))
+	})
+}
@@ -0,0 +1,200 @@
+package failure
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/avila-r/failure/ctx"
+	"github.com/avila-r/failure/property"
+	"github.com/avila-r/failure/report"
+	"github.com/avila-r/failure/severity"
+	"github.com/avila-r/failure/tags"
+)
+
+var _ json.Marshaler = (*Error)(nil)
+
+type document struct {
+	ID         string            `json:"id,omitempty"`
+	Class      string            `json:"class"`
+	Code       string            `json:"code,omitempty"`
+	Severity   string            `json:"severity,omitempty"`
+	Message    string            `json:"message,omitempty"`
+	Time       *time.Time        `json:"time,omitempty"`
+	Duration   string            `json:"duration,omitempty"`
+	Domain     string            `json:"domain,omitempty"`
+	Tags       tags.Tags         `json:"tags,omitempty"`
+	Trace      string            `json:"trace,omitempty"`
+	Span       string            `json:"span,omitempty"`
+	Goroutine  uint64            `json:"goroutine,omitempty"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Hint       string            `json:"hint,omitempty"`
+	Public     string            `json:"public,omitempty"`
+	Owner      string            `json:"owner,omitempty"`
+	Context    ctx.Context       `json:"context,omitempty"`
+	Properties Properties        `json:"properties,omitempty"`
+	Underlying []string          `json:"underlying,omitempty"`
+	Trail      string            `json:"trail,omitempty"`
+	Returns    []string          `json:"returns,omitempty"`
+	Sealed     bool              `json:"sealed,omitempty"`
+	Cause      *json.RawMessage  `json:"cause,omitempty"`
+	Report     *report.Metadata  `json:"report,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler.
+// The outermost document holds the views of the whole chain, such as the trail or the
+// properties with their provenance, as returned by AllProperties, along with the metadata
+// of the process when enabled, see report.SetEnabled. Nested causes only hold their own fields.
+func (e *Error) MarshalJSON() ([]byte, error) {
+	e.audit()
+
+	doc, err := e.document()
+	if err != nil {
+		return nil, err
+	}
+
+	if report.Enabled() {
+		metadata := report.Get()
+		doc.Report = &metadata
+	}
+
+	return json.Marshal(doc)
+}
+
+// document describes e along with the whole chain.
+func (e *Error) document() (document, error) {
+	doc := document{
+		ID:      e.id,
+		Class:   e.Class().Name,
+		Code:    e.Code(),
+		Message: e.message,
+		Domain:  e.Domain(),
+		Tags:    e.Tags(),
+		Trace: Deep(e, func(e *Error) string {
+			return e.trace
+		}),
+		Span:       e.Span(),
+		Goroutine:  e.Goroutine(),
+		Labels:     e.Labels(),
+		Hint:       e.Hint(),
+		Public:     e.Public(),
+		Owner:      e.Owner(),
+		Context:    e.Context(),
+		Properties: e.AllProperties(),
+		Trail:      e.Trail(),
+		Returns:    e.Returns(),
+		Sealed:     e.sealed,
+	}
+
+	if s := e.Severity(); s != severity.Unset {
+		doc.Severity = s.String()
+	}
+
+	if t := e.Time(); !t.IsZero() {
+		doc.Time = utc(t)
+	}
+
+	if duration := e.Duration(); duration != 0 {
+		doc.Duration = duration.String()
+	}
+
+	return doc, e.describe(&doc)
+}
+
+// own describes only the fields set on e itself, as a nested cause.
+func (e *Error) own() (document, error) {
+	doc := document{
+		ID:        e.id,
+		Class:     e.Class().Name,
+		Code:      e.Code(),
+		Message:   e.message,
+		Domain:    e.domain,
+		Tags:      e.tags,
+		Trace:     e.trace,
+		Span:      e.span,
+		Goroutine: e.goroutine,
+		Labels:    e.labels,
+		Hint:      e.hint,
+		Public:    e.public,
+		Owner:     e.owner,
+		Sealed:    e.sealed,
+	}
+
+	if len(e.context) > 0 {
+		doc.Context = ctx.Evaluate(e.context)
+	}
+
+	seen := map[string]struct{}{}
+	for m := e.properties; m != nil; m = m.Next {
+		if m.Key == property.Underlying {
+			continue
+		}
+
+		_, shadowed := seen[m.Key]
+		seen[m.Key] = struct{}{}
+
+		doc.Properties = append(doc.Properties, PropertyEntry{
+			Key:      m.Key,
+			Value:    m.Value,
+			Origin:   e,
+			Shadowed: shadowed,
+		})
+	}
+
+	if len(e.returns) > 0 {
+		doc.Returns = frames(e.returns)
+	}
+
+	if s := e.severity.Or(e.class.Severity); s != severity.Unset {
+		doc.Severity = s.String()
+	}
+
+	if t := e.time; !t.IsZero() {
+		doc.Time = utc(t)
+	} else if !e.created.IsZero() {
+		doc.Time = utc(e.created)
+	}
+
+	if e.duration != 0 {
+		doc.Duration = e.duration.String()
+	}
+
+	return doc, e.describe(&doc)
+}
+
+// describe fills the underlying errors and the cause of doc.
+func (e *Error) describe(doc *document) error {
+	for _, err := range e.Underlying() {
+		doc.Underlying = append(doc.Underlying, err.Error())
+	}
+
+	if e.cause != nil {
+		raw, err := marshalCause(e.cause)
+		if err != nil {
+			return err
+		}
+		doc.Cause = &raw
+	}
+
+	return nil
+}
+
+func utc(t time.Time) *time.Time {
+	t = t.In(time.UTC)
+	return &t
+}
+
+func marshalCause(cause error) (json.RawMessage, error) {
+	if casted := Cast(cause); casted != nil {
+		doc, err := casted.own()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(doc)
+	}
+
+	return json.Marshal(struct {
+		Message string `json:"message"`
+	}{
+		Message: cause.Error(),
+	})
+}
@@ -0,0 +1,72 @@
+package failure
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/avila-r/failure/property"
+	"github.com/avila-r/failure/trait"
+)
+
+// Measure runs f and, if it fails, decorates the error with the elapsed
+// duration and the name of the operation. Errors caused by an elapsed
+// context deadline are classified as TimeoutElapsed.
+func Measure(ctx context.Context, name string, f func(context.Context) error) error {
+	start := now()
+	if err := f(ctx); err != nil {
+		return measured(ctx, name, err, start)
+	}
+
+	return nil
+}
+
+// MeasureValue is the same as Measure, for functions returning a value.
+func MeasureValue[T any](ctx context.Context, name string, f func(context.Context) (T, error)) (T, error) {
+	start := now()
+	value, err := f(ctx)
+	if err != nil {
+		return value, measured(ctx, name, err, start)
+	}
+
+	return value, nil
+}
+
+func measured(ctx context.Context, name string, err error, start time.Time) *Error {
+	err = CancelCause(ctx, err)
+
+	builder := Builder(transparentWrapper)
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) && !Has(err, trait.Timeout) {
+		builder = Builder(TimeoutElapsed)
+	}
+
+	// the stack trace starts at the caller of Measure
+	return builder.
+		Message(name).
+		Cause(err).
+		With(property.Operation, name).
+		skipping(1).
+		Build().
+		WithDuration(elapsed(start))
+}
+
+// formatDurations writes the duration of every layer of the chain which has one.
+func (e *Error) formatDurations(w io.Writer) {
+	traverse(e, func(e *Error) {
+		if e.duration == 0 {
+			return
+		}
+
+		label := e.message
+		if operation, ok := e.properties.Get(property.Operation); ok {
+			label = fmt.Sprint(operation)
+		}
+		if label == "" {
+			label = e.class.Name
+		}
+
+		_, _ = io.WriteString(w, "\n took "+e.duration.String()+" in "+label)
+	})
+}
@@ -7,6 +7,8 @@ const (
 	ClassModifierTransparent ClassModifier = 1
 	// ClassModifierOmitStackTrace is a type modifier; an error type with such modifier omits the stack trace collection upon creation of an error instance
 	ClassModifierOmitStackTrace ClassModifier = 2
+	// ClassModifierGoroutine is a type modifier; an error type with such modifier records the creating goroutine's ID and pprof labels
+	ClassModifierGoroutine ClassModifier = 3
 )
 
 type Modifiers interface {
@@ -15,12 +17,27 @@ type Modifiers interface {
 	ReplaceWith(new Modifiers) Modifiers
 }
 
+// GoroutineRecorder is implemented by modifiers which may record the creating goroutine.
+// It is kept apart from Modifiers so that existing implementations remain valid.
+type GoroutineRecorder interface {
+	RecordGoroutine() bool
+}
+
+// RecordGoroutine reports whether m records the creating goroutine,
+// which is never the case when m doesn't implement GoroutineRecorder.
+func RecordGoroutine(m Modifiers) bool {
+	recorder, ok := m.(GoroutineRecorder)
+	return ok && recorder.RecordGoroutine()
+}
+
 type (
 	NoModifiers struct{}
 )
 
 var (
 	None Modifiers = NoModifiers{}
+
+	_ GoroutineRecorder = NoModifiers{}
 )
 
 // CollectStackTrace implements Modifiers.
@@ -38,15 +55,22 @@ func (n NoModifiers) Transparent() bool {
 	return false
 }
 
+// RecordGoroutine implements GoroutineRecorder.
+func (n NoModifiers) RecordGoroutine() bool {
+	return false
+}
+
 type (
 	ClassModifiers struct {
 		OmitStackTrace bool
 		IsTransparent  bool
+		Goroutine      bool
 	}
 )
 
 var (
-	_ Modifiers = ClassModifiers{}
+	_ Modifiers         = ClassModifiers{}
+	_ GoroutineRecorder = ClassModifiers{}
 )
 
 func Class(modifiers ...ClassModifier) Modifiers {
@@ -58,6 +82,8 @@ func Class(modifiers ...ClassModifier) Modifiers {
 			m.OmitStackTrace = true
 		case ClassModifierTransparent:
 			m.IsTransparent = true
+		case ClassModifierGoroutine:
+			m.Goroutine = true
 		}
 	}
 
@@ -79,6 +105,11 @@ func (c ClassModifiers) Transparent() bool {
 	return c.IsTransparent
 }
 
+// RecordGoroutine implements GoroutineRecorder.
+func (c ClassModifiers) RecordGoroutine() bool {
+	return c.Goroutine
+}
+
 type (
 	InheritedModifiers struct {
 		Parent   Modifiers
@@ -87,7 +118,8 @@ type (
 )
 
 var (
-	_ Modifiers = InheritedModifiers{}
+	_ Modifiers         = InheritedModifiers{}
+	_ GoroutineRecorder = InheritedModifiers{}
 )
 
 func Inherited(modifiers Modifiers) Modifiers {
@@ -116,3 +148,8 @@ func (i InheritedModifiers) ReplaceWith(new Modifiers) Modifiers {
 func (i InheritedModifiers) Transparent() bool {
 	return i.Parent.Transparent() || i.Override.Transparent()
 }
+
+// RecordGoroutine implements GoroutineRecorder.
+func (i InheritedModifiers) RecordGoroutine() bool {
+	return RecordGoroutine(i.Parent) || RecordGoroutine(i.Override)
+}
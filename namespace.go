@@ -5,6 +5,7 @@ import (
 
 	"github.com/avila-r/failure/id"
 	"github.com/avila-r/failure/modifier"
+	"github.com/avila-r/failure/severity"
 	"github.com/avila-r/failure/trait"
 )
 
@@ -14,6 +15,7 @@ type ErrorNamespace struct {
 	Name      string
 	Traits    []trait.Trait
 	Modifiers modifier.Modifiers
+	Severity  severity.Severity
 }
 
 func Namespace(name string, traits ...trait.Trait) ErrorNamespace {
@@ -37,6 +39,7 @@ func (n ErrorNamespace) Namespace(name string, traits ...trait.Trait) ErrorNames
 		Name:      fmt.Sprintf("%s.%s", n.Name, name),
 		Traits:    append([]trait.Trait{}, traits...),
 		Modifiers: modifier.Inherited(n.Modifiers),
+		Severity:  n.Severity,
 	}
 
 	namespace.register()
@@ -50,7 +53,13 @@ func (n ErrorNamespace) Apply(modifiers ...modifier.ClassModifier) ErrorNamespac
 	return n
 }
 
-func (n ErrorNamespace) Class(name string, traits ...trait.Trait) *ErrorClass {
+// WithSeverity sets the default severity of classes created from the namespace and its subnamespaces.
+func (n ErrorNamespace) WithSeverity(s severity.Severity) ErrorNamespace {
+	n.Severity = s
+	return n
+}
+
+func (n ErrorNamespace) Class(name string, options ...ClassOption) *ErrorClass {
 	class := &ErrorClass{
 		Namespace: n,
 		Parent:    nil,
@@ -61,14 +70,13 @@ func (n ErrorNamespace) Class(name string, traits ...trait.Trait) *ErrorClass {
 			for trait := range n.CollectTraits() {
 				result[trait] = true
 			}
-			for _, trait := range traits {
-				result[trait] = true
-			}
 			return result
 		}(),
 		Modifiers: modifier.Inherited(n.Modifiers),
+		Severity:  n.Severity,
 	}
 
+	class.configure(options...)
 	class.register()
 
 	return class
@@ -106,7 +114,5 @@ func (n ErrorNamespace) register() {
 	defer Registry.mu.Unlock()
 
 	Registry.Namespaces = append(Registry.Namespaces, n)
-	for _, s := range Registry.Listeners {
-		s.OnNamespaceCreated(n)
-	}
+	publish(event{namespace: &n})
 }
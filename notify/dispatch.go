@@ -0,0 +1,181 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/avila-r/failure"
+)
+
+// Metrics describes the delivery state of a sink.
+type Metrics struct {
+	Enqueued     uint64
+	Dropped      uint64
+	Deduplicated uint64
+	Delivered    uint64
+	Failed       uint64
+	Pending      int
+	Capacity     int
+}
+
+type fingerprint struct {
+	key string
+	at  time.Time
+}
+
+type dispatcher struct {
+	sink    Sink
+	options Options
+	queue   chan *failure.Error
+	done    chan struct{}
+	once    sync.Once
+
+	mu   sync.Mutex
+	seen map[string]time.Time
+	// order lists recorded fingerprints from the oldest to the most recent
+	order []fingerprint
+
+	enqueued     atomic.Uint64
+	dropped      atomic.Uint64
+	deduplicated atomic.Uint64
+	delivered    atomic.Uint64
+	failed       atomic.Uint64
+}
+
+func dispatch(sink Sink, options Options) *dispatcher {
+	d := &dispatcher{
+		sink:    sink,
+		options: options,
+		queue:   make(chan *failure.Error, options.QueueSize),
+		done:    make(chan struct{}),
+		seen:    map[string]time.Time{},
+	}
+
+	go d.loop()
+
+	return d
+}
+
+func (d *dispatcher) enqueue(err *failure.Error) {
+	if d.options.DedupWindow <= 0 {
+		d.push(err)
+		return
+	}
+
+	key, now := d.options.Fingerprint(err), time.Now()
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if last, ok := d.seen[key]; ok && now.Sub(last) < d.options.DedupWindow {
+		d.deduplicated.Add(1)
+		return
+	}
+
+	// errors dropped on a full queue must not silence the following ones
+	if d.push(err) {
+		d.record(key, now)
+	}
+}
+
+func (d *dispatcher) push(err *failure.Error) bool {
+	select {
+	case d.queue <- err:
+		d.enqueued.Add(1)
+		return true
+	default:
+		d.dropped.Add(1)
+		return false
+	}
+}
+
+// record remembers key until the dedup window elapses, forgetting the oldest
+// fingerprints once DedupCapacity is reached.
+func (d *dispatcher) record(key string, now time.Time) {
+	for len(d.order) > 0 {
+		oldest := d.order[0]
+		if now.Sub(oldest.at) < d.options.DedupWindow && len(d.seen) < d.options.DedupCapacity {
+			break
+		}
+
+		d.order = d.order[1:]
+		if d.seen[oldest.key].Equal(oldest.at) {
+			delete(d.seen, oldest.key)
+		}
+	}
+
+	d.seen[key] = now
+	d.order = append(d.order, fingerprint{key: key, at: now})
+}
+
+func (d *dispatcher) loop() {
+	defer close(d.done)
+
+	ticker := time.NewTicker(d.options.FlushInterval)
+	defer ticker.Stop()
+
+	batch := make([]*failure.Error, 0, d.options.BatchSize)
+	for {
+		select {
+		case err, ok := <-d.queue:
+			if !ok {
+				d.flush(batch)
+				return
+			}
+
+			batch = append(batch, err)
+			if len(batch) >= d.options.BatchSize {
+				d.flush(batch)
+				batch = make([]*failure.Error, 0, d.options.BatchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				d.flush(batch)
+				batch = make([]*failure.Error, 0, d.options.BatchSize)
+			}
+		}
+	}
+}
+
+func (d *dispatcher) flush(batch []*failure.Error) {
+	if len(batch) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), d.options.SendTimeout)
+	defer cancel()
+
+	if err := d.sink.Send(ctx, batch); err != nil {
+		d.failed.Add(uint64(len(batch)))
+		return
+	}
+
+	d.delivered.Add(uint64(len(batch)))
+}
+
+func (d *dispatcher) close(ctx context.Context) error {
+	d.once.Do(func() {
+		close(d.queue)
+	})
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *dispatcher) snapshot() Metrics {
+	return Metrics{
+		Enqueued:     d.enqueued.Load(),
+		Dropped:      d.dropped.Load(),
+		Deduplicated: d.deduplicated.Load(),
+		Delivered:    d.delivered.Load(),
+		Failed:       d.failed.Load(),
+		Pending:      len(d.queue),
+		Capacity:     cap(d.queue),
+	}
+}
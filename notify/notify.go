@@ -0,0 +1,256 @@
+package notify
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/trait"
+)
+
+// Sink delivers batches of errors to an external destination.
+type Sink interface {
+	Send(ctx context.Context, batch []*failure.Error) error
+}
+
+// SinkFunc adapts an ordinary function to the Sink interface.
+type SinkFunc func(ctx context.Context, batch []*failure.Error) error
+
+// Send implements Sink.
+func (f SinkFunc) Send(ctx context.Context, batch []*failure.Error) error {
+	return f(ctx, batch)
+}
+
+// Match selects errors by their metadata. Every non-zero field must match.
+type Match struct {
+	Owner  string
+	Domain string
+	Class  *failure.ErrorClass
+	Trait  *trait.Trait
+	// Labels must all be among the pprof labels recorded by the error
+	Labels map[string]string
+}
+
+func (m Match) Matches(err *failure.Error) bool {
+	if m.Owner != "" && err.Owner() != m.Owner {
+		return false
+	}
+
+	if m.Domain != "" && err.Domain() != m.Domain {
+		return false
+	}
+
+	if m.Class != nil && !failure.Extends(err, m.Class) {
+		return false
+	}
+
+	if m.Trait != nil && !err.Has(*m.Trait) {
+		return false
+	}
+
+	if len(m.Labels) > 0 {
+		labels := err.Labels()
+		for key, value := range m.Labels {
+			if actual, ok := labels[key]; !ok || actual != value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+type Options struct {
+	// QueueSize bounds the number of pending errors per sink, errors are dropped when it is full
+	QueueSize int
+	// BatchSize is the maximum number of errors handed to a sink at once
+	BatchSize int
+	// FlushInterval is the maximum time an error waits for its batch to fill up
+	FlushInterval time.Duration
+	// SendTimeout bounds every call to Sink.Send
+	SendTimeout time.Duration
+	// DedupWindow is the period during which errors with the same fingerprint are delivered only once,
+	// zero disables deduplication
+	DedupWindow time.Duration
+	// DedupCapacity bounds the number of fingerprints remembered during DedupWindow,
+	// the oldest ones being forgotten first
+	DedupCapacity int
+	// Fingerprint identifies duplicated errors, defaults to Fingerprint
+	Fingerprint func(*failure.Error) string
+}
+
+var DefaultOptions = Options{
+	QueueSize:     1024,
+	BatchSize:     64,
+	FlushInterval: time.Second,
+	SendTimeout:   10 * time.Second,
+	DedupWindow:   time.Minute,
+	DedupCapacity: 4096,
+	Fingerprint:   Fingerprint,
+}
+
+type route struct {
+	match Match
+	sinks []string
+}
+
+// Router dispatches errors to the sinks of every route they match,
+// or to the fallback sinks when none matches. Delivery is asynchronous.
+type Router struct {
+	options  Options
+	mu       sync.RWMutex
+	routes   []route
+	fallback []string
+	sinks    map[string]*dispatcher
+	closed   bool
+}
+
+func New(options ...Options) *Router {
+	o := DefaultOptions
+	if len(options) > 0 {
+		o = options[0]
+	}
+
+	if o.QueueSize <= 0 {
+		o.QueueSize = DefaultOptions.QueueSize
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = DefaultOptions.BatchSize
+	}
+	if o.FlushInterval <= 0 {
+		o.FlushInterval = DefaultOptions.FlushInterval
+	}
+	if o.SendTimeout <= 0 {
+		o.SendTimeout = DefaultOptions.SendTimeout
+	}
+	if o.DedupCapacity <= 0 {
+		o.DedupCapacity = DefaultOptions.DedupCapacity
+	}
+	if o.Fingerprint == nil {
+		o.Fingerprint = Fingerprint
+	}
+
+	return &Router{
+		options: o,
+		sinks:   map[string]*dispatcher{},
+	}
+}
+
+// Sink registers a named sink and starts its delivery loop.
+func (r *Router) Sink(name string, sink Sink) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.sinks[name]; ok {
+		panic("sink " + name + " is already registered")
+	}
+
+	r.sinks[name] = dispatch(sink, r.options)
+	return r
+}
+
+// Route sends errors matching m to the named sinks.
+func (r *Router) Route(m Match, sinks ...string) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.routes = append(r.routes, route{
+		match: m,
+		sinks: sinks,
+	})
+	return r
+}
+
+// Fallback sends errors matching no route to the named sinks.
+func (r *Router) Fallback(sinks ...string) *Router {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = sinks
+	return r
+}
+
+// Notify enqueues err for delivery, it never blocks.
+// Errors which are not *failure.Error are decorated first.
+func (r *Router) Notify(err error) {
+	if err == nil {
+		return
+	}
+
+	casted := failure.Cast(err)
+	if casted == nil {
+		casted = failure.Decorate(err, "")
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.closed {
+		return
+	}
+
+	targets := map[string]struct{}{}
+	for _, route := range r.routes {
+		if route.match.Matches(casted) {
+			for _, name := range route.sinks {
+				targets[name] = struct{}{}
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		for _, name := range r.fallback {
+			targets[name] = struct{}{}
+		}
+	}
+
+	for name := range targets {
+		if d, ok := r.sinks[name]; ok {
+			d.enqueue(casted)
+		}
+	}
+}
+
+// Metrics returns delivery counters of every sink, by name.
+func (r *Router) Metrics() map[string]Metrics {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	result := make(map[string]Metrics, len(r.sinks))
+	for name, d := range r.sinks {
+		result[name] = d.snapshot()
+	}
+	return result
+}
+
+// Close stops accepting errors and flushes pending ones, until ctx is done.
+func (r *Router) Close(ctx context.Context) error {
+	r.mu.Lock()
+	r.closed = true
+	dispatchers := make([]*dispatcher, 0, len(r.sinks))
+	for _, d := range r.sinks {
+		dispatchers = append(dispatchers, d)
+	}
+	r.mu.Unlock()
+
+	errs := []error{}
+	for _, d := range dispatchers {
+		errs = append(errs, d.close(ctx))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Fingerprint identifies an error by its class, message, domain and owner.
+func Fingerprint(err *failure.Error) string {
+	h := sha256.New()
+	for _, part := range []string{err.Class().Name, err.Message(), err.Domain(), err.Owner()} {
+		h.Write([]byte(part))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil)[:16])
+}
@@ -0,0 +1,191 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/report"
+)
+
+// Writer writes every error to w in the %+v format, one block per error,
+// followed by the process metadata of the batch when enabled, see report.SetEnabled.
+func Writer(w io.Writer) Sink {
+	mu := &sync.Mutex{}
+	return SinkFunc(func(_ context.Context, batch []*failure.Error) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		for _, err := range batch {
+			if _, e := fmt.Fprintf(w, "%+v\n", err); e != nil {
+				return e
+			}
+		}
+
+		if report.Enabled() {
+			if _, e := fmt.Fprintf(w, "report: %s\n", report.Get()); e != nil {
+				return e
+			}
+		}
+		return nil
+	})
+}
+
+func Stderr() Sink {
+	return Writer(os.Stderr)
+}
+
+// Webhook posts every batch as a JSON array to url.
+func Webhook(url string, client ...*http.Client) Sink {
+	c := http.DefaultClient
+	if len(client) > 0 && client[0] != nil {
+		c = client[0]
+	}
+
+	return SinkFunc(func(ctx context.Context, batch []*failure.Error) error {
+		body, err := json.Marshal(batch)
+		if err != nil {
+			return err
+		}
+
+		request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return err
+		}
+		request.Header.Set("Content-Type", "application/json")
+
+		response, err := c.Do(request)
+		if err != nil {
+			return err
+		}
+		defer response.Body.Close()
+		_, _ = io.Copy(io.Discard, response.Body)
+
+		if response.StatusCode >= 300 {
+			return failure.ExternalError.New("webhook %s responded with status %d", url, response.StatusCode)
+		}
+
+		return nil
+	})
+}
+
+// RotatingFile appends errors as JSON lines to the file at path. When the file
+// exceeds size bytes, it is renamed to path.1, shifting older files up to path.<backups>.
+type RotatingFile struct {
+	path    string
+	size    int64
+	backups int
+
+	mu      sync.Mutex
+	file    *os.File
+	written int64
+}
+
+var _ Sink = (*RotatingFile)(nil)
+
+func File(path string, size int64, backups int) *RotatingFile {
+	return &RotatingFile{
+		path:    path,
+		size:    size,
+		backups: backups,
+	}
+}
+
+// Send implements Sink.
+func (f *RotatingFile) Send(_ context.Context, batch []*failure.Error) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, err := range batch {
+		line, e := json.Marshal(err)
+		if e != nil {
+			return e
+		}
+		line = append(line, '\n')
+
+		if e := f.open(int64(len(line))); e != nil {
+			return e
+		}
+
+		n, e := f.file.Write(line)
+		f.written += int64(n)
+		if e != nil {
+			return e
+		}
+	}
+
+	return nil
+}
+
+func (f *RotatingFile) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.file == nil {
+		return nil
+	}
+
+	err := f.file.Close()
+	f.file = nil
+	return err
+}
+
+// open makes sure a file with room for n more bytes is open.
+func (f *RotatingFile) open(n int64) error {
+	if f.file != nil && (f.size <= 0 || f.written+n <= f.size || f.written == 0) {
+		return nil
+	}
+
+	if f.file != nil {
+		if err := f.file.Close(); err != nil {
+			return err
+		}
+		f.file = nil
+
+		if err := f.rotate(); err != nil {
+			return err
+		}
+	}
+
+	file, err := os.OpenFile(f.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	f.file, f.written = file, info.Size()
+	if f.size > 0 && f.written > 0 && f.written+n > f.size {
+		return f.open(n)
+	}
+
+	return nil
+}
+
+func (f *RotatingFile) rotate() error {
+	if f.backups <= 0 {
+		return os.Remove(f.path)
+	}
+
+	for i := f.backups - 1; i > 0; i-- {
+		from := f.path + "." + strconv.Itoa(i)
+		if _, err := os.Stat(from); err == nil {
+			if err := os.Rename(from, f.path+"."+strconv.Itoa(i+1)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return os.Rename(f.path, f.path+".1")
+}
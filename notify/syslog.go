@@ -0,0 +1,33 @@
+//go:build !windows && !plan9 && !js && !wasip1
+
+package notify
+
+import (
+	"context"
+	"log/syslog"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/report"
+)
+
+// Syslog writes every error to the local syslog daemon, using tag as the program name.
+func Syslog(priority syslog.Priority, tag string) (Sink, error) {
+	writer, err := syslog.New(priority, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	return SinkFunc(func(_ context.Context, batch []*failure.Error) error {
+		suffix := ""
+		if report.Enabled() {
+			suffix = " [" + report.Get().String() + "]"
+		}
+
+		for _, err := range batch {
+			if e := writer.Err(err.Summary() + suffix); e != nil {
+				return e
+			}
+		}
+		return nil
+	}), nil
+}
@@ -0,0 +1,124 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"sync"
+
+	"github.com/avila-r/failure"
+)
+
+// ContentType is the media type of problem details documents.
+const ContentType = "application/problem+json"
+
+// Details is a problem details document, see https://www.rfc-editor.org/rfc/rfc9457
+type Details struct {
+	Type     string
+	Title    string
+	Status   int
+	Detail   string
+	Instance string
+
+	// Extensions are additional members, rendered next to the standard ones
+	Extensions map[string]any
+}
+
+// Extension adds members to the details of an error, see Extend.
+type Extension func(err *failure.Error, details *Details)
+
+var extensions = struct {
+	mu   sync.RWMutex
+	list []Extension
+}{}
+
+// Extend registers an extension applied, in registration order, by From.
+func Extend(extension Extension) {
+	extensions.mu.Lock()
+	defer extensions.mu.Unlock()
+
+	extensions.list = append(extensions.list, extension)
+}
+
+// From describes err as problem details. Only public information is exposed:
+// the documentation of the class as type, its description as title,
+// its status and code, the public message as detail and the trace identifier.
+// Errors which are not *failure.Error are described as internal server errors.
+func From(err error) Details {
+	details := Details{
+		Type:       "about:blank",
+		Status:     http.StatusInternalServerError,
+		Extensions: map[string]any{},
+	}
+
+	var e *failure.Error
+	if !errors.As(err, &e) {
+		details.Title = http.StatusText(details.Status)
+		return details
+	}
+
+	if status := e.Status(); status != 0 {
+		details.Status = status
+	}
+
+	if docs := e.Docs(); docs != "" {
+		details.Type = docs
+	}
+
+	details.Title = e.Description()
+	if details.Title == "" {
+		details.Title = http.StatusText(details.Status)
+	}
+
+	details.Detail = e.Public()
+
+	if code := e.Code(); code != "" {
+		details.Extensions["code"] = code
+	}
+	details.Extensions["trace"] = e.Trace()
+
+	extensions.mu.RLock()
+	defer extensions.mu.RUnlock()
+
+	for _, extension := range extensions.list {
+		extension(e, &details)
+	}
+
+	return details
+}
+
+// Write responds with the problem details of err.
+func Write(w http.ResponseWriter, err error) error {
+	details := From(err)
+
+	body, e := json.Marshal(details)
+	if e != nil {
+		return e
+	}
+
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	_, e = w.Write(body)
+	return e
+}
+
+// MarshalJSON implements json.Marshaler, extensions never overriding standard members.
+func (d Details) MarshalJSON() ([]byte, error) {
+	members := maps.Clone(d.Extensions)
+	if members == nil {
+		members = map[string]any{}
+	}
+
+	members["type"] = d.Type
+	members["title"] = d.Title
+	members["status"] = d.Status
+	if d.Detail != "" {
+		members["detail"] = d.Detail
+	}
+	if d.Instance != "" {
+		members["instance"] = d.Instance
+	}
+
+	return json.Marshal(members)
+}
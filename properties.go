@@ -0,0 +1,196 @@
+package failure
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/avila-r/failure/property"
+)
+
+var (
+	_ fmt.Formatter  = (Properties)(nil)
+	_ json.Marshaler = (Properties)(nil)
+)
+
+// PropertyEntry is a single property found along an error chain,
+// together with the error that attached it.
+type PropertyEntry struct {
+	Key   string
+	Value any
+
+	// Origin is the error which the property was set on
+	Origin *Error
+	// Depth is the position of Origin in the chain, zero being the outermost error
+	Depth int
+	// Shadowed reports whether a property with the same key
+	// set closer to the outermost error takes precedence over this one
+	Shadowed bool
+	// Hidden reports whether the property is set below an opaque error,
+	// where Property doesn't look keys up
+	Hidden bool
+}
+
+// Properties is an ordered view of every property in an error chain.
+// Entries are listed from the outermost error to the innermost one and,
+// within the same error, from the most recently set to the oldest.
+// The effective entry of a key, as returned by Property, is its first one which is not hidden.
+type Properties []PropertyEntry
+
+func (e *Error) AllProperties() Properties {
+	var (
+		result    = Properties{}
+		seen      = map[string]struct{}{}
+		depth     = 0
+		reachable = e.reachable()
+	)
+
+	Recurse(e, func(e *Error) {
+		_, visible := reachable[e]
+
+		for m := e.properties; m != nil; m = m.Next {
+			if m.Key == property.Underlying {
+				continue
+			}
+
+			_, shadowed := seen[m.Key]
+			if visible {
+				seen[m.Key] = struct{}{}
+			}
+
+			result = append(result, PropertyEntry{
+				Key:      m.Key,
+				Value:    m.Value,
+				Origin:   e,
+				Depth:    depth,
+				Shadowed: shadowed,
+				Hidden:   !visible,
+			})
+		}
+		depth++
+	})
+
+	return result
+}
+
+// reachable returns the errors of the chain Property looks keys up on:
+// e itself and, through transparent and translucent errors, their causes.
+func (e *Error) reachable() map[*Error]struct{} {
+	result := map[*Error]struct{}{}
+
+	var visit func(e *Error)
+	visit = func(e *Error) {
+		if _, ok := result[e]; ok {
+			return
+		}
+		result[e] = struct{}{}
+
+		if e.transparent || e.translucent {
+			for _, next := range nearest(e.cause) {
+				visit(next)
+			}
+		}
+	}
+
+	visit(e)
+	return result
+}
+
+func AllProperties(err error) Properties {
+	if err := locate(err); err != nil {
+		return err.AllProperties()
+	}
+
+	return Properties{}
+}
+
+// Effective returns the entries which are neither shadowed nor hidden.
+func (p Properties) Effective() Properties {
+	result := make(Properties, 0, len(p))
+	for _, entry := range p {
+		if !entry.Shadowed && !entry.Hidden {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+func (p Properties) Get(key string) property.Result {
+	for _, entry := range p {
+		if entry.Key == key && !entry.Hidden {
+			return property.Result{
+				Value: entry.Value,
+				Ok:    true,
+			}
+		}
+	}
+
+	return property.Empty()
+}
+
+// Format implements the Formatter interface.
+//
+// Supported verbs:
+//
+//	%s		effective properties only
+//	%v		effective properties only
+//	%+v		every property, one per line, with its origin
+func (p Properties) Format(state fmt.State, verb rune) {
+	switch verb {
+	case 'v':
+		if state.Flag('+') {
+			for _, entry := range p {
+				_, _ = io.WriteString(state, "\n "+entry.String())
+			}
+			return
+		}
+		fallthrough
+	case 's':
+		effective := p.Effective()
+		strs := make([]string, len(effective))
+		for i, entry := range effective {
+			strs[i] = fmt.Sprintf("%s: %v", entry.Key, entry.Value)
+		}
+		_, _ = io.WriteString(state, "{"+strings.Join(strs, ", ")+"}")
+	}
+}
+
+func (p PropertyEntry) String() string {
+	text := fmt.Sprintf("%s=%v set by %s (depth %d)", p.Key, p.Value, p.Origin.class.Name, p.Depth)
+	if p.Shadowed {
+		text += " [shadowed]"
+	}
+	if p.Hidden {
+		text += " [hidden]"
+	}
+	return text
+}
+
+// MarshalJSON implements json.Marshaler
+func (p Properties) MarshalJSON() ([]byte, error) {
+	type entry struct {
+		Key      string `json:"key"`
+		Value    any    `json:"value"`
+		Class    string `json:"class"`
+		Message  string `json:"message,omitempty"`
+		Depth    int    `json:"depth"`
+		Shadowed bool   `json:"shadowed,omitempty"`
+		Hidden   bool   `json:"hidden,omitempty"`
+	}
+
+	entries := make([]entry, len(p))
+	for i := range p {
+		entries[i] = entry{
+			Key:      p[i].Key,
+			Value:    p[i].Value,
+			Class:    p[i].Origin.class.Name,
+			Message:  p[i].Origin.message,
+			Depth:    p[i].Depth,
+			Shadowed: p[i].Shadowed,
+			Hidden:   p[i].Hidden,
+		}
+	}
+
+	return json.Marshal(entries)
+}
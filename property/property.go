@@ -9,6 +9,7 @@ const (
 	Context    = "context"
 	Payload    = "payload"
 	Underlying = "underlying"
+	Operation  = "operation"
 )
 
 // List represents map of properties.
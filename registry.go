@@ -1,13 +1,25 @@
 package failure
 
-import "sync"
+import (
+	"slices"
+	"sync"
+
+	"github.com/avila-r/failure/schema"
+	"github.com/avila-r/failure/trait"
+)
 
 var Registry = struct {
-	Listeners  []RegistryListener
+	// Listeners lists the listeners currently subscribed.
+	//
+	// Deprecated: Use Subscribe, listeners appended directly aren't notified.
+	Listeners []RegistryListener
+
 	Namespaces []ErrorNamespace
 	Classes    []*ErrorClass
+	Traits     []trait.Trait
 
-	mu sync.Mutex
+	mu            sync.RWMutex
+	subscriptions []*subscription
 }{}
 
 type RegistryListener interface {
@@ -18,14 +30,346 @@ type RegistryListener interface {
 	OnClassCreated(t *ErrorClass)
 }
 
-func Subscribe(listener RegistryListener) {
-	for _, namespace := range Registry.Namespaces {
-		listener.OnNamespaceCreated(namespace)
+// TraitListener is implemented by registry listeners which are notified of traits as well.
+type TraitListener interface {
+	// OnTraitCreated is called exactly once for each trait
+	OnTraitCreated(t trait.Trait)
+}
+
+func init() {
+	trait.Observe(func(t trait.Trait) {
+		Registry.mu.Lock()
+		defer Registry.mu.Unlock()
+
+		Registry.Traits = append(Registry.Traits, t)
+		publish(event{trait: &t})
+	})
+}
+
+// SubscribeOption configures a subscription.
+type SubscribeOption func(*subscription)
+
+// Async delivers events from a dedicated goroutine, in order, so that slow listeners
+// don't block the creation of classes, and may use the registry, see Subscribe.
+func Async() SubscribeOption {
+	return func(s *subscription) {
+		s.async = true
+	}
+}
+
+// Subscribe notifies listener of every namespace, class and, if it implements TraitListener,
+// trait registered so far, then of those registered afterwards. No event is missed or
+// repeated when registration happens concurrently. The returned function unsubscribes
+// listener: no event is delivered once it returns, except for an asynchronous one being
+// delivered at that moment, pending ones being discarded.
+//
+// Unless subscribed with Async, listener is called synchronously while the registry is locked:
+// it must neither query the registry nor create namespaces, classes or traits, including through
+// failure.Trait or trait.New, as that would deadlock.
+func Subscribe(listener RegistryListener, options ...SubscribeOption) (unsubscribe func()) {
+	s := &subscription{listener: listener}
+	for _, option := range options {
+		option(s)
+	}
+	if s.async {
+		s.pending = sync.NewCond(&s.mu)
+		go s.run()
+	}
+
+	Registry.mu.Lock()
+	defer Registry.mu.Unlock()
+
+	for i := range Registry.Traits {
+		s.deliver(event{trait: &Registry.Traits[i]})
+	}
+
+	for i := range Registry.Namespaces {
+		s.deliver(event{namespace: &Registry.Namespaces[i]})
 	}
 
 	for _, class := range Registry.Classes {
-		listener.OnClassCreated(class)
+		s.deliver(event{class: class})
 	}
 
+	Registry.subscriptions = append(Registry.subscriptions, s)
 	Registry.Listeners = append(Registry.Listeners, listener)
+
+	once := sync.Once{}
+	return func() {
+		once.Do(func() {
+			Registry.mu.Lock()
+			defer Registry.mu.Unlock()
+
+			if i := slices.Index(Registry.subscriptions, s); i >= 0 {
+				Registry.subscriptions = slices.Delete(Registry.subscriptions, i, i+1)
+			}
+
+			listeners := make([]RegistryListener, 0, len(Registry.subscriptions))
+			for _, other := range Registry.subscriptions {
+				listeners = append(listeners, other.listener)
+			}
+			Registry.Listeners = listeners
+
+			s.close()
+		})
+	}
+}
+
+// publish delivers e to every subscription, the registry being locked.
+func publish(e event) {
+	for _, s := range Registry.subscriptions {
+		s.deliver(e)
+	}
+}
+
+// event is the creation of exactly one of a namespace, a class or a trait.
+type event struct {
+	namespace *ErrorNamespace
+	class     *ErrorClass
+	trait     *trait.Trait
+}
+
+type subscription struct {
+	listener RegistryListener
+	async    bool
+
+	mu      sync.Mutex
+	pending *sync.Cond
+	queue   []event
+	closed  bool
+}
+
+func (s *subscription) deliver(e event) {
+	if !s.async {
+		s.notify(e)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.queue = append(s.queue, e)
+	s.pending.Signal()
+}
+
+func (s *subscription) notify(e event) {
+	switch {
+	case e.namespace != nil:
+		s.listener.OnNamespaceCreated(*e.namespace)
+	case e.class != nil:
+		s.listener.OnClassCreated(e.class)
+	case e.trait != nil:
+		if listener, ok := s.listener.(TraitListener); ok {
+			listener.OnTraitCreated(*e.trait)
+		}
+	}
+}
+
+func (s *subscription) run() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for {
+		for len(s.queue) == 0 && !s.closed {
+			s.pending.Wait()
+		}
+		if s.closed {
+			return
+		}
+
+		e := s.queue[0]
+		s.queue = s.queue[1:]
+
+		s.mu.Unlock()
+		s.notify(e)
+		s.mu.Lock()
+	}
+}
+
+func (s *subscription) close() {
+	if !s.async {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.closed = true
+	s.queue = nil
+	s.pending.Broadcast()
+}
+
+// Schemas returns the property schema declared by every registered class, keyed by class name.
+func Schemas() map[string]*schema.Schema {
+	Registry.mu.RLock()
+	defer Registry.mu.RUnlock()
+
+	result := make(map[string]*schema.Schema)
+	for _, class := range Registry.Classes {
+		if class.Schema != nil {
+			result[class.Name] = class.Schema
+		}
+	}
+
+	return result
+}
+
+// The queries below are safe for concurrent use with the creation of classes and namespaces.
+// Classes of the private synthetic namespace are never returned.
+
+// LookupClass returns the first registered class with the given full name, such as common.illegal_state.
+func LookupClass(name string) (*ErrorClass, bool) {
+	return find(func(c *ErrorClass) bool {
+		return c.Name == name
+	})
+}
+
+func ClassByID(id uint64) (*ErrorClass, bool) {
+	return find(func(c *ErrorClass) bool {
+		return c.ID == id
+	})
+}
+
+// ClassesIn returns the classes of namespace and of its subnamespaces, in creation order.
+func ClassesIn(namespace ErrorNamespace) []*ErrorClass {
+	return filter(namespace.Contains)
+}
+
+// Children returns the direct subclasses of class.
+func Children(class *ErrorClass) []*ErrorClass {
+	return filter(func(c *ErrorClass) bool {
+		return c.Parent != nil && c.Parent.ID == class.ID
+	})
+}
+
+// Ancestors returns the parents of class, from the closest to the root one.
+func Ancestors(class *ErrorClass) []*ErrorClass {
+	Registry.mu.RLock()
+	defer Registry.mu.RUnlock()
+
+	result := []*ErrorClass{}
+	for parent := class.Parent; parent != nil; parent = parent.Parent {
+		// parents are copies taken when subclasses are created, prefer the registered class
+		if i := slices.IndexFunc(Registry.Classes, func(c *ErrorClass) bool {
+			return c.ID == parent.ID
+		}); i >= 0 {
+			result = append(result, Registry.Classes[i])
+		} else {
+			result = append(result, parent)
+		}
+	}
+
+	return result
+}
+
+func ClassesWithTrait(t trait.Trait) []*ErrorClass {
+	return filter(func(c *ErrorClass) bool {
+		return c.Has(t)
+	})
+}
+
+func find(predicate func(*ErrorClass) bool) (*ErrorClass, bool) {
+	Registry.mu.RLock()
+	defer Registry.mu.RUnlock()
+
+	for _, class := range Registry.Classes {
+		if !synthetic.Contains(class) && predicate(class) {
+			return class, true
+		}
+	}
+
+	return nil, false
+}
+
+func filter(predicate func(*ErrorClass) bool) []*ErrorClass {
+	Registry.mu.RLock()
+	defer Registry.mu.RUnlock()
+
+	result := []*ErrorClass{}
+	for _, class := range Registry.Classes {
+		if !synthetic.Contains(class) && predicate(class) {
+			result = append(result, class)
+		}
+	}
+
+	return result
+}
+
+// Node is an element of the class hierarchy returned by Tree:
+// either a namespace, whose children are its subnamespaces followed by its top-level classes,
+// or a class, whose children are its subclasses.
+type Node struct {
+	Namespace *ErrorNamespace
+	Class     *ErrorClass
+	Children  []*Node
+}
+
+func (n *Node) Name() string {
+	if n.Class != nil {
+		return n.Class.Name
+	}
+
+	return n.Namespace.Name
+}
+
+// Tree returns the root namespaces with their subnamespaces, classes and subclasses, in creation order.
+func Tree() []*Node {
+	Registry.mu.RLock()
+	defer Registry.mu.RUnlock()
+
+	var (
+		roots      = []*Node{}
+		namespaces = map[uint64]*Node{}
+		classes    = map[uint64]*Node{}
+	)
+
+	for i := range Registry.Namespaces {
+		namespace := Registry.Namespaces[i]
+		if namespace.ID == synthetic.ID {
+			continue
+		}
+
+		node := &Node{Namespace: &namespace}
+		namespaces[namespace.ID] = node
+
+		if namespace.Parent == nil {
+			roots = append(roots, node)
+		} else if parent, ok := namespaces[namespace.Parent.ID]; ok {
+			parent.Children = append(parent.Children, node)
+		}
+	}
+
+	for _, class := range Registry.Classes {
+		if synthetic.Contains(class) {
+			continue
+		}
+
+		node := &Node{Class: class}
+		classes[class.ID] = node
+
+		if class.Parent != nil {
+			if parent, ok := classes[class.Parent.ID]; ok {
+				parent.Children = append(parent.Children, node)
+			}
+		} else if namespace, ok := namespaces[class.Namespace.ID]; ok {
+			namespace.Children = append(namespace.Children, node)
+		}
+	}
+
+	return roots
+}
+
+// Walk visits the nodes of Tree depth first, along with their depth, starting at zero.
+// Children of a node are skipped when visit returns false.
+func Walk(visit func(node *Node, depth int) bool) {
+	var walk func(nodes []*Node, depth int)
+	walk = func(nodes []*Node, depth int) {
+		for _, node := range nodes {
+			if visit(node, depth) {
+				walk(node.Children, depth+1)
+			}
+		}
+	}
+
+	walk(Tree(), 0)
 }
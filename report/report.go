@@ -0,0 +1,129 @@
+package report
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// Metadata describes the running process. It is part of every crash report and,
+// once enabled, attached to errors when they are exported or serialized,
+// rather than stored on every error instance.
+type Metadata struct {
+	Module   string         `json:"module,omitempty"`
+	Version  string         `json:"version,omitempty"`
+	Revision string         `json:"revision,omitempty"`
+	Dirty    bool           `json:"dirty,omitempty"`
+	Go       string         `json:"go,omitempty"`
+	Host     string         `json:"host,omitempty"`
+	Fields   map[string]any `json:"fields,omitempty"`
+}
+
+var (
+	process = sync.OnceValue(func() Metadata {
+		m := Metadata{
+			Go: runtime.Version(),
+		}
+
+		if host, err := os.Hostname(); err == nil {
+			m.Host = host
+		}
+
+		info, ok := debug.ReadBuildInfo()
+		if !ok {
+			return m
+		}
+
+		m.Module, m.Version = info.Main.Path, info.Main.Version
+		if info.GoVersion != "" {
+			m.Go = info.GoVersion
+		}
+
+		for _, setting := range info.Settings {
+			switch setting.Key {
+			case "vcs.revision":
+				m.Revision = setting.Value
+			case "vcs.modified":
+				m.Dirty = setting.Value == "true"
+			}
+		}
+
+		return m
+	})
+
+	mu     sync.RWMutex
+	fields = map[string]any{}
+
+	enabled atomic.Bool
+)
+
+// Set registers a custom field, such as the service name or the environment.
+func Set(key string, value any) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	fields[key] = value
+}
+
+func Unset(key string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	delete(fields, key)
+}
+
+// SetEnabled toggles the attachment of metadata to exported errors, which is disabled by default
+// as it discloses the host and build of the process wherever errors end up.
+func SetEnabled(value bool) {
+	enabled.Store(value)
+}
+
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Get returns the metadata of the process, build information being read only once.
+func Get() Metadata {
+	m := process()
+
+	mu.RLock()
+	defer mu.RUnlock()
+
+	if len(fields) > 0 {
+		m.Fields = maps.Clone(fields)
+	}
+
+	return m
+}
+
+func (m Metadata) String() string {
+	parts := []string{}
+
+	if m.Module != "" {
+		parts = append(parts, m.Module+"@"+m.Version)
+	}
+	if m.Revision != "" {
+		revision := "revision=" + m.Revision
+		if m.Dirty {
+			revision += "+dirty"
+		}
+		parts = append(parts, revision)
+	}
+	if m.Go != "" {
+		parts = append(parts, "go="+m.Go)
+	}
+	if m.Host != "" {
+		parts = append(parts, "host="+m.Host)
+	}
+	for _, key := range slices.Sorted(maps.Keys(m.Fields)) {
+		parts = append(parts, fmt.Sprintf("%s=%v", key, m.Fields[key]))
+	}
+
+	return strings.Join(parts, " ")
+}
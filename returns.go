@@ -0,0 +1,95 @@
+package failure
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"runtime"
+	"slices"
+
+	"github.com/avila-r/failure/modifier"
+)
+
+// returnTraceWrapper is the private class used to record return traces of foreign errors
+var returnTraceWrapper = synthetic.Class("return").Apply(
+	modifier.ClassModifierTransparent,
+	modifier.ClassModifierOmitStackTrace,
+)
+
+// Here records its caller on the return path of err and returns it, which is much
+// cheaper than collecting a stack trace at every layer:
+//
+//	if err := Find(id); err != nil {
+//		return failure.Here(err)
+//	}
+//
+// Errors which are not *Error are wrapped transparently.
+func Here(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	pc := [1]uintptr{}
+	if runtime.Callers(2, pc[:]) == 0 {
+		return err
+	}
+
+	casted := Cast(err)
+	if casted == nil {
+		casted = Builder(returnTraceWrapper).
+			Cause(err).
+			Build()
+	} else {
+		casted = casted.mutable()
+	}
+
+	casted.returns = append(casted.returns[:len(casted.returns):len(casted.returns)], pc[0])
+	return casted
+}
+
+// Returns lists the return path of the error, from the innermost frame to the outermost one,
+// each formatted as "function file:line".
+func (e *Error) Returns() []string {
+	layers := [][]uintptr{}
+	traverse(e, func(e *Error) {
+		if len(e.returns) > 0 {
+			layers = append(layers, e.returns)
+		}
+	})
+	slices.Reverse(layers)
+
+	result := []string{}
+	for _, pcs := range layers {
+		result = append(result, frames(pcs)...)
+	}
+
+	return result
+}
+
+// frames formats program counters as "function file:line".
+func frames(pcs []uintptr) []string {
+	result := []string{}
+
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		result = append(result, fmt.Sprintf("%s %s:%d", frame.Function, filepath.Base(frame.File), frame.Line))
+		if !more {
+			break
+		}
+	}
+
+	return result
+}
+
+func (e *Error) formatReturns(w io.Writer) {
+	returns := e.Returns()
+	if len(returns) == 0 {
+		return
+	}
+
+	_, _ = io.WriteString(w, "\n returned through:")
+	for _, frame := range returns {
+		_, _ = io.WriteString(w, "\n  <- "+frame)
+	}
+}
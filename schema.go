@@ -0,0 +1,170 @@
+package failure
+
+import (
+	"flag"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+
+	"github.com/avila-r/failure/schema"
+)
+
+// Enforcement defines what happens when an error breaks a contract
+// declared by its class, such as a property schema.
+type Enforcement int32
+
+const (
+	// EnforceAuto applies the default policy, see SetDefaultEnforcement
+	EnforceAuto Enforcement = iota
+	// EnforcePanic panics with an IllegalState error
+	EnforcePanic
+	// EnforceReport logs the violation and attaches an IllegalState error as underlying
+	EnforceReport
+	// EnforceIgnore does nothing
+	EnforceIgnore
+)
+
+var (
+	defaultEnforcement atomic.Int32
+	schemaEnforcement  atomic.Int32
+)
+
+// SetDefaultEnforcement replaces the policy EnforceAuto stands for and returns the previous one.
+// Initially, and when set to EnforceAuto, it is EnforcePanic under `go test` and EnforceReport otherwise.
+func SetDefaultEnforcement(e Enforcement) Enforcement {
+	return Enforcement(defaultEnforcement.Swap(int32(e)))
+}
+
+// SetSchemaEnforcement replaces the policy applied on schema violations and returns the previous one.
+func SetSchemaEnforcement(e Enforcement) Enforcement {
+	return Enforcement(schemaEnforcement.Swap(int32(e)))
+}
+
+func SchemaEnforcement() Enforcement {
+	return Enforcement(schemaEnforcement.Load())
+}
+
+func (e Enforcement) resolve() Enforcement {
+	if e != EnforceAuto {
+		return e
+	}
+
+	if e := Enforcement(defaultEnforcement.Load()); e != EnforceAuto {
+		return e
+	}
+
+	// test binaries register their flags, which doesn't require importing testing
+	if flag.Lookup("test.v") != nil {
+		return EnforcePanic
+	}
+
+	return EnforceReport
+}
+
+// enforce applies the policy to err, which breaks a contract described by violation.
+func (e Enforcement) enforce(err *Error, violation *Error) *Error {
+	switch e.resolve() {
+	case EnforcePanic:
+		violation.Panic()
+	case EnforceReport:
+		slog.Default().Warn(violation.Error(), "class", err.Class().Name)
+		return err.Also(violation)
+	}
+
+	return err
+}
+
+// conform validates the properties of e against the class schema. Required properties
+// are only enforced when complete: errors created without properties usually receive them
+// through With, so they are checked when first exported instead, see audit.
+func (e *Error) conform(complete bool) *Error {
+	if e.transparent || e.class.Schema == nil {
+		return e
+	}
+
+	if violations := e.violations(complete); len(violations) > 0 {
+		return SchemaEnforcement().enforce(e, violated(e.class, violations...))
+	}
+
+	return e
+}
+
+// audit enforces required properties on every error of the chain of e, when it is exported
+// through JSON, logs or %+v. As errors can't be modified at that point, violations are only
+// logged under EnforceReport.
+func (e *Error) audit() {
+	traverse(e, func(e *Error) {
+		if e.transparent || e.class.Schema == nil {
+			return
+		}
+
+		violations := e.violations(true)
+		if len(violations) == 0 {
+			return
+		}
+
+		violation := violated(e.class, violations...)
+		switch SchemaEnforcement().resolve() {
+		case EnforcePanic:
+			violation.Panic()
+		case EnforceReport:
+			slog.Default().Warn(violation.Error(), "class", e.class.Name, "id", e.id)
+		}
+	})
+}
+
+// Validate checks the properties of e against the schema of its class, required ones included,
+// regardless of the enforcement policy. It returns an IllegalState error describing the violations, if any.
+func (e *Error) Validate() error {
+	if e.transparent || e.class.Schema == nil {
+		return nil
+	}
+
+	if violations := e.violations(true); len(violations) > 0 {
+		return violated(e.class, violations...)
+	}
+
+	return nil
+}
+
+func (e *Error) violations(complete bool) []schema.Violation {
+	if complete {
+		return e.class.Schema.Validate(func(key string) (any, bool) {
+			return e.properties.Get(key)
+		})
+	}
+
+	violations := []schema.Violation{}
+	seen := map[string]struct{}{}
+	for m := e.properties; m != nil; m = m.Next {
+		if _, ok := seen[m.Key]; ok {
+			continue
+		}
+		seen[m.Key] = struct{}{}
+
+		if violation, ok := e.class.Schema.Check(m.Key, m.Value); ok {
+			violations = append(violations, violation)
+		}
+	}
+
+	return violations
+}
+
+// conformProperty validates a single property against the effective class schema.
+func (e *Error) conformProperty(key string, value any) *Error {
+	violation, ok := e.Class().Schema.Check(key, value)
+	if !ok {
+		return e
+	}
+
+	return SchemaEnforcement().enforce(e, violated(e.Class(), violation))
+}
+
+func violated(c *ErrorClass, violations ...schema.Violation) *Error {
+	details := make([]string, len(violations))
+	for i := range violations {
+		details[i] = violations[i].String()
+	}
+
+	return IllegalState.New("schema violation on %s: %s", c.Name, strings.Join(details, "; "))
+}
@@ -0,0 +1,163 @@
+package schema
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// Field describes a single property key expected by an error class.
+type Field struct {
+	Key      string
+	Type     reflect.Type
+	Required bool
+}
+
+func Required[T any](key string) Field {
+	return Field{
+		Key:      key,
+		Type:     reflect.TypeFor[T](),
+		Required: true,
+	}
+}
+
+func Optional[T any](key string) Field {
+	return Field{
+		Key:      key,
+		Type:     reflect.TypeFor[T](),
+		Required: false,
+	}
+}
+
+func (f Field) String() string {
+	if f.Required {
+		return fmt.Sprintf("%s %v (required)", f.Key, f.Type)
+	}
+
+	return fmt.Sprintf("%s %v (optional)", f.Key, f.Type)
+}
+
+// Accepts reports whether value may be stored under the field's key.
+func (f Field) Accepts(value any) bool {
+	if f.Type == nil {
+		return true
+	}
+
+	if value == nil {
+		switch f.Type.Kind() {
+		case reflect.Interface, reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan:
+			return true
+		default:
+			return false
+		}
+	}
+
+	return reflect.TypeOf(value).AssignableTo(f.Type)
+}
+
+// Schema is a set of property keys declared by an error class.
+// Keys that are not declared are allowed and never validated.
+type Schema struct {
+	Fields []Field
+}
+
+func New(fields ...Field) *Schema {
+	return (*Schema)(nil).Extend(fields...)
+}
+
+// Extend returns a new schema holding the fields of s and the given ones,
+// with the latter replacing the declarations of the same key.
+func (s *Schema) Extend(fields ...Field) *Schema {
+	result := &Schema{}
+
+	if s != nil {
+		result.Fields = append(result.Fields, s.Fields...)
+	}
+
+	for _, field := range fields {
+		replaced := false
+		for i := range result.Fields {
+			if result.Fields[i].Key == field.Key {
+				result.Fields[i], replaced = field, true
+				break
+			}
+		}
+
+		if !replaced {
+			result.Fields = append(result.Fields, field)
+		}
+	}
+
+	return result
+}
+
+func (s *Schema) Lookup(key string) (Field, bool) {
+	if s == nil {
+		return Field{}, false
+	}
+
+	for _, field := range s.Fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+
+	return Field{}, false
+}
+
+// Violation describes a property that does not conform to its declaration.
+type Violation struct {
+	Field   Field
+	Value   any
+	Missing bool
+}
+
+func (v Violation) String() string {
+	if v.Missing {
+		return fmt.Sprintf("missing required property %q", v.Field.Key)
+	}
+
+	return fmt.Sprintf("property %q must be %v, got %T", v.Field.Key, v.Field.Type, v.Value)
+}
+
+// Check validates a single key/value pair against the schema.
+func (s *Schema) Check(key string, value any) (Violation, bool) {
+	field, ok := s.Lookup(key)
+	if !ok || field.Accepts(value) {
+		return Violation{}, false
+	}
+
+	return Violation{
+		Field: field,
+		Value: value,
+	}, true
+}
+
+// Validate checks every declared field using get to look properties up.
+func (s *Schema) Validate(get func(key string) (any, bool)) []Violation {
+	if s == nil {
+		return nil
+	}
+
+	violations := []Violation{}
+	for _, field := range s.Fields {
+		value, ok := get(field.Key)
+		if !ok {
+			if field.Required {
+				violations = append(violations, Violation{
+					Field:   field,
+					Missing: true,
+				})
+			}
+			continue
+		}
+
+		if !field.Accepts(value) {
+			violations = append(violations, Violation{
+				Field: field,
+				Value: value,
+			})
+		}
+	}
+
+	return violations
+}
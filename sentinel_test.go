@@ -0,0 +1,191 @@
+package failure_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/ctx"
+	"github.com/avila-r/failure/tags"
+)
+
+var (
+	ErrSentinel = failure.DataUnavailable.Sentinel("record not found")
+	// ErrLookalike shares the message and class of ErrSentinel, only its identity differs
+	ErrLookalike = failure.DataUnavailable.Sentinel("record not found")
+)
+
+// snapshot captures what enrichment must never change on a sentinel.
+type snapshot struct {
+	id, message, owner, public, hint, domain, trace, span string
+	tags                                                  tags.Tags
+	context                                               ctx.Context
+	properties                                            int
+	underlying                                            int
+	returns                                               int
+	duration                                              time.Duration
+}
+
+func snapshotOf(err *failure.Error) snapshot {
+	return snapshot{
+		id:         err.ID(),
+		message:    err.Message(),
+		owner:      err.Owner(),
+		public:     err.Public(),
+		hint:       err.Hint(),
+		domain:     err.Domain(),
+		trace:      err.Trace(),
+		span:       err.Span(),
+		tags:       err.Tags(),
+		context:    err.Context(),
+		properties: len(err.AllProperties()),
+		underlying: len(err.Underlying()),
+		returns:    len(err.Returns()),
+		duration:   err.Duration(),
+	}
+}
+
+func (s snapshot) equal(other snapshot) bool {
+	return s.id == other.id &&
+		s.message == other.message &&
+		s.owner == other.owner &&
+		s.public == other.public &&
+		s.hint == other.hint &&
+		s.domain == other.domain &&
+		s.trace == other.trace &&
+		s.span == other.span &&
+		maps.Equal(s.tags, other.tags) &&
+		maps.Equal(s.context, other.context) &&
+		s.properties == other.properties &&
+		s.underlying == other.underlying &&
+		s.returns == other.returns &&
+		s.duration == other.duration
+}
+
+// enrichments derive an error from the sentinel in every supported way.
+var enrichments = map[string]func(i int) error{
+	"With": func(i int) error {
+		return ErrSentinel.With("request", i)
+	},
+	"WithOwner": func(i int) error {
+		return ErrSentinel.WithOwner(fmt.Sprint("owner-", i))
+	},
+	"WithPublic": func(i int) error {
+		return ErrSentinel.WithPublic(fmt.Sprint("public-", i))
+	},
+	"WithHint": func(i int) error {
+		return ErrSentinel.WithHint(fmt.Sprint("hint-", i))
+	},
+	"WithTrace": func(i int) error {
+		return ErrSentinel.WithTrace(fmt.Sprint("trace-", i)).WithSpan(fmt.Sprint("span-", i))
+	},
+	"WithTags": func(i int) error {
+		return ErrSentinel.WithTags(tags.Tags{"request": fmt.Sprint(i)})
+	},
+	"WithContext": func(i int) error {
+		return ErrSentinel.WithContext(ctx.Context{"request": i})
+	},
+	"WithDomain": func(i int) error {
+		return ErrSentinel.WithDomain(fmt.Sprint("domain-", i))
+	},
+	"WithDuration": func(i int) error {
+		return ErrSentinel.WithDuration(time.Duration(i))
+	},
+	"WithCause": func(i int) error {
+		return ErrSentinel.WithCause(fmt.Errorf("cause %d", i))
+	},
+	"Chain": func(i int) error {
+		chain := ErrSentinel.Chain()
+		return chain.
+			Owner(fmt.Sprint("owner-", i)).
+			Public(fmt.Sprint("public-", i)).
+			Hint(fmt.Sprint("hint-", i)).
+			Trace(fmt.Sprint("trace-", i)).
+			Span(fmt.Sprint("span-", i)).
+			Tags(tags.Tags{"request": fmt.Sprint(i)}).
+			Context(ctx.Context{"request": i}).
+			In(fmt.Sprint("domain-", i)).
+			Duration(time.Duration(i)).
+			Done()
+	},
+	"Here": func(i int) error {
+		return failure.Here(ErrSentinel)
+	},
+	"Within": func(i int) error {
+		c := failure.ContextWithTrace(context.Background(), fmt.Sprint("trace-", i))
+		c = failure.ContextWithTags(c, tags.Tags{"request": fmt.Sprint(i)})
+		return ErrSentinel.Within(c)
+	},
+	"Also": func(i int) error {
+		return ErrSentinel.Also(fmt.Errorf("underlying %d", i))
+	},
+}
+
+func TestSentinelConcurrentEnrichment(t *testing.T) {
+	before := snapshotOf(ErrSentinel)
+
+	const workers = 16
+	for name, enrich := range enrichments {
+		t.Run(name, func(t *testing.T) {
+			wg := sync.WaitGroup{}
+			results := make(chan error, workers)
+
+			for i := range workers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					results <- enrich(i)
+				}()
+			}
+
+			wg.Wait()
+			close(results)
+
+			for err := range results {
+				if err == error(ErrSentinel) {
+					t.Fatalf("%s returned the sentinel itself", name)
+				}
+				if !errors.Is(err, ErrSentinel) {
+					t.Fatalf("%s: %v does not match the sentinel", name, err)
+				}
+				if errors.Is(err, ErrLookalike) {
+					t.Fatalf("%s: %v matches a sentinel it wasn't derived from", name, err)
+				}
+			}
+
+			if after := snapshotOf(ErrSentinel); !before.equal(after) {
+				t.Fatalf("%s mutated the sentinel: %+v, was %+v", name, after, before)
+			}
+		})
+	}
+}
+
+func TestSentinelRejectsInPlaceMutation(t *testing.T) {
+	for name, mutate := range map[string]func(){
+		"Decorate": ErrSentinel.Decorate,
+		"Enhance":  ErrSentinel.Enhance,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if err := failure.Try(mutate); err == nil {
+				t.Fatalf("%s did not panic on a sentinel", name)
+			}
+		})
+	}
+}
+
+func TestSentinelMatchesByIdentity(t *testing.T) {
+	if errors.Is(failure.DataUnavailable.New("record not found"), ErrSentinel) {
+		t.Fatal("an error with the same message matches the sentinel")
+	}
+	if errors.Is(ErrLookalike, ErrSentinel) {
+		t.Fatal("a sentinel with the same message matches the sentinel")
+	}
+	if !errors.Is(ErrSentinel.With("request", 1).WithOwner("owner"), ErrSentinel) {
+		t.Fatal("a copy derived twice does not match the sentinel")
+	}
+}
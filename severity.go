@@ -0,0 +1,92 @@
+package failure
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+
+	"github.com/avila-r/failure/severity"
+)
+
+// SeverityRule defines how the severity of an error chain is resolved.
+type SeverityRule int32
+
+const (
+	// SeverityMax picks the highest severity across the chain
+	SeverityMax SeverityRule = iota
+	// SeverityOutermost picks the severity closest to the outermost error
+	SeverityOutermost
+)
+
+// LevelCritical is the slog level of critical errors
+const LevelCritical = slog.LevelError + 4
+
+var severityRule atomic.Int32
+
+// SetSeverityRule replaces the rule used to resolve the severity of error chains and returns the previous one.
+func SetSeverityRule(rule SeverityRule) SeverityRule {
+	return SeverityRule(severityRule.Swap(int32(rule)))
+}
+
+// Severity returns the severity of err, according to the current SeverityRule.
+// Each error of the chain contributes its own severity or the one of its class.
+func (e *Error) Severity() severity.Severity {
+	rule, result := SeverityRule(severityRule.Load()), severity.Unset
+
+	Recurse(e, func(e *Error) {
+		current := e.severity.Or(e.class.Severity)
+
+		switch rule {
+		case SeverityOutermost:
+			result = result.Or(current)
+		default:
+			result = max(result, current)
+		}
+	})
+
+	return result
+}
+
+func (e *Error) WithSeverity(s severity.Severity) *Error {
+	e = e.mutable()
+	e.severity = s
+	return e
+}
+
+func Severity(err error) severity.Severity {
+	if casted := locate(err); casted != nil {
+		return casted.Severity()
+	}
+
+	return severity.Unset
+}
+
+// Level maps the severity of err to a slog level.
+// Errors without severity are logged at slog.LevelError.
+func Level(err error) slog.Level {
+	switch Severity(err) {
+	case severity.Debug:
+		return slog.LevelDebug
+	case severity.Info:
+		return slog.LevelInfo
+	case severity.Warning:
+		return slog.LevelWarn
+	case severity.Critical:
+		return LevelCritical
+	default:
+		return slog.LevelError
+	}
+}
+
+// Log logs err at the level of its severity, using the default logger if logger is nil.
+func Log(ctx context.Context, logger *slog.Logger, err error) {
+	if err == nil {
+		return
+	}
+
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	logger.Log(ctx, Level(err), err.Error(), slog.Any("error", err))
+}
@@ -0,0 +1,65 @@
+package severity
+
+import (
+	"encoding"
+	"fmt"
+	"strings"
+)
+
+type Severity int
+
+const (
+	// Unset severity is inherited from the enclosing class or namespace
+	Unset Severity = iota
+	Debug
+	Info
+	Warning
+	Error
+	Critical
+)
+
+var (
+	_ encoding.TextMarshaler   = Unset
+	_ encoding.TextUnmarshaler = (*Severity)(nil)
+)
+
+var names = map[Severity]string{
+	Unset:    "unset",
+	Debug:    "debug",
+	Info:     "info",
+	Warning:  "warning",
+	Error:    "error",
+	Critical: "critical",
+}
+
+func (s Severity) String() string {
+	if name, ok := names[s]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// Or returns s, or other when s is unset.
+func (s Severity) Or(other Severity) Severity {
+	if s == Unset {
+		return other
+	}
+	return s
+}
+
+// MarshalText implements encoding.TextMarshaler
+func (s Severity) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler
+func (s *Severity) UnmarshalText(text []byte) error {
+	for severity, name := range names {
+		if strings.EqualFold(name, string(text)) {
+			*s = severity
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown severity %q", text)
+}
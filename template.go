@@ -0,0 +1,72 @@
+package failure
+
+var _ error = (*ErrorTemplate)(nil)
+
+// ErrorTemplate declares an error without creating it. Unlike package-level
+// errors built through New, a template carries no stack trace or trail:
+// those are captured when an error is produced from it at the return site.
+// Errors produced by a template match it through Is.
+type ErrorTemplate struct {
+	class   *ErrorClass
+	message string
+}
+
+func Template(message string) *ErrorTemplate {
+	return DefaultClass.Template(message)
+}
+
+func (c *ErrorClass) Template(message string) *ErrorTemplate {
+	return &ErrorTemplate{
+		class:   c,
+		message: message,
+	}
+}
+
+// Error implements the error interface.
+// A result is the unformatted message of the template.
+func (t *ErrorTemplate) Error() string {
+	return t.message
+}
+
+func (t *ErrorTemplate) Class() *ErrorClass {
+	return t.class
+}
+
+// New creates an error from the template, formatting its message with v.
+func (t *ErrorTemplate) New(v ...any) *Error {
+	return t.build(1, nil, v...)
+}
+
+// Here is an alias of New, reading better at the return site.
+func (t *ErrorTemplate) Here(v ...any) *Error {
+	return t.build(1, nil, v...)
+}
+
+func (t *ErrorTemplate) Wrap(cause error, v ...any) *Error {
+	return t.build(1, cause, v...)
+}
+
+// build creates an error from the template, its stack trace starting skip frames
+// above the caller of build.
+func (t *ErrorTemplate) build(skip int, cause error, v ...any) *Error {
+	builder := Builder(t.class).
+		Message(t.message, v...).
+		skipping(skip)
+
+	if cause != nil {
+		builder = builder.Cause(cause)
+	}
+
+	err := builder.Build()
+	err.template = t
+	return err
+}
+
+func (t *ErrorTemplate) Is(err error) bool {
+	if other, ok := err.(*ErrorTemplate); ok {
+		return t == other
+	}
+
+	casted := Cast(err)
+	return casted != nil && casted.template == t
+}
@@ -1,15 +1,57 @@
 package trait
 
-import "github.com/avila-r/failure/id"
+import (
+	"sync"
+
+	"github.com/avila-r/failure/id"
+)
 
 type Trait struct {
 	ID    uint64
 	Label string
 }
 
+var registry = struct {
+	mu        sync.Mutex
+	traits    []Trait
+	observers []func(Trait)
+}{}
+
 func New(label string) Trait {
-	return Trait{
+	t := Trait{
 		ID:    id.Next(),
 		Label: label,
 	}
+
+	registry.mu.Lock()
+	registry.traits = append(registry.traits, t)
+	observers := registry.observers[:len(registry.observers):len(registry.observers)]
+	registry.mu.Unlock()
+
+	// observers are called outside of the lock, so that they may create traits themselves
+	for _, observer := range observers {
+		observer(t)
+	}
+
+	return t
+}
+
+// Observe calls observer with every trait created so far, then with every trait created afterwards.
+func Observe(observer func(Trait)) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	for _, t := range registry.traits {
+		observer(t)
+	}
+
+	registry.observers = append(registry.observers, observer)
+}
+
+// All returns every trait created so far, in creation order.
+func All() []Trait {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+
+	return append([]Trait(nil), registry.traits...)
 }
@@ -0,0 +1,158 @@
+package failure
+
+import (
+	"log/slog"
+	"slices"
+
+	"github.com/avila-r/failure/trait"
+)
+
+type translation struct {
+	class  *ErrorClass
+	trait  *trait.Trait
+	target *ErrorClass
+}
+
+func (t translation) matches(err error) bool {
+	if t.class != nil {
+		return Extends(err, t.class)
+	}
+
+	return Has(err, *t.trait)
+}
+
+// Translator rewrites errors crossing a boundary between layers, such as
+// a repository and an API, into the classes of the outer layer.
+// Rules are consulted in declaration order, the first matching one wins.
+// It is meant to be declared once and is not safe for concurrent configuration.
+type Translator struct {
+	rules       []translation
+	opaque      bool
+	keys        []string
+	fallback    *ErrorClass
+	enforcement Enforcement
+}
+
+func NewTranslator() *Translator {
+	return &Translator{}
+}
+
+// Class translates errors extending source into target.
+func (t *Translator) Class(source, target *ErrorClass) *Translator {
+	t.rules = append(t.rules, translation{class: source, target: target})
+	return t
+}
+
+// Trait translates errors having trait into target.
+func (t *Translator) Trait(trait trait.Trait, target *ErrorClass) *Translator {
+	t.rules = append(t.rules, translation{trait: &trait, target: target})
+	return t
+}
+
+// Opaque seals the original errors as causes of the translated ones, see Wrap.
+// By default, translated errors are translucent: they match their target class
+// as well as the original errors through Is, As, Extends, Has and Property,
+// see ErrorBuilder.Translucent.
+func (t *Translator) Opaque() *Translator {
+	t.opaque = true
+	return t
+}
+
+// Copy carries the properties with keys over to the translated errors.
+func (t *Translator) Copy(keys ...string) *Translator {
+	t.keys = append(t.keys, keys...)
+	return t
+}
+
+// Fallback translates errors matched by no rule into target.
+func (t *Translator) Fallback(target *ErrorClass) *Translator {
+	t.fallback = target
+	return t
+}
+
+// Enforce sets the policy applied when an error matched by no rule crosses
+// the boundary without a fallback. By default, it panics under `go test` and
+// logs otherwise, see SetDefaultEnforcement, the error being returned unchanged.
+func (t *Translator) Enforce(e Enforcement) *Translator {
+	t.enforcement = e
+	return t
+}
+
+// Translate returns err rewritten into its target class, keeping the original
+// message and err as cause. Errors which already belong to a target class,
+// as well as nil, are returned unchanged.
+func (t *Translator) Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if t.translated(err) {
+		return err
+	}
+
+	for _, rule := range t.rules {
+		if rule.matches(err) {
+			return t.translate(err, rule.target)
+		}
+	}
+
+	if t.fallback != nil {
+		return t.translate(err, t.fallback)
+	}
+
+	class := classify(err).class
+	if e := locate(err); e != nil {
+		class = e.Class()
+	}
+
+	switch t.enforcement.resolve() {
+	case EnforcePanic:
+		IllegalState.New("untranslated error of class %s crossed the boundary: %v", class.Name, err).Panic()
+	case EnforceReport:
+		slog.Default().Warn("untranslated error crossed the boundary", "class", class.Name, "error", err)
+	}
+
+	return err
+}
+
+func (t *Translator) translate(err error, target *ErrorClass) error {
+	message := err.Error()
+	if e := locate(err); e != nil {
+		message = e.Message()
+	}
+
+	builder := Builder(target).
+		Message(message).
+		Cause(err)
+
+	if t.opaque {
+		builder = builder.Sealed()
+	} else {
+		builder = builder.Translucent()
+	}
+
+	translated := builder.Build()
+
+	for _, key := range t.keys {
+		if result := Property(err, key); result.Ok {
+			translated = translated.With(key, result.Value)
+		}
+	}
+
+	return translated
+}
+
+// translated reports whether err already belongs to one of the target classes.
+func (t *Translator) translated(err error) bool {
+	targets := make([]*ErrorClass, 0, len(t.rules)+1)
+	for _, rule := range t.rules {
+		targets = append(targets, rule.target)
+	}
+	if t.fallback != nil {
+		targets = append(targets, t.fallback)
+	}
+
+	return slices.ContainsFunc(targets, func(target *ErrorClass) bool {
+		return Extends(err, target)
+	})
+}
@@ -0,0 +1,48 @@
+package validation
+
+import (
+	"strconv"
+)
+
+// Path locates a value within validated input, such as items[2].price.
+// The zero value is the root of the input.
+type Path string
+
+func Field(name string) Path {
+	return Path(name)
+}
+
+func (p Path) Field(name string) Path {
+	if p == "" {
+		return Path(name)
+	}
+
+	return p + "." + Path(name)
+}
+
+func (p Path) Index(i int) Path {
+	return p + "[" + Path(strconv.Itoa(i)) + "]"
+}
+
+// Key locates the value of a map entry, as in labels[env].
+func (p Path) Key(key string) Path {
+	return p + "[" + Path(key) + "]"
+}
+
+// Join locates other relative to p.
+func (p Path) Join(other Path) Path {
+	switch {
+	case p == "":
+		return other
+	case other == "":
+		return p
+	case other[0] == '[':
+		return p + other
+	default:
+		return p + "." + other
+	}
+}
+
+func (p Path) String() string {
+	return string(p)
+}
@@ -0,0 +1,198 @@
+package validation
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/avila-r/failure"
+	"github.com/avila-r/failure/problem"
+	"github.com/avila-r/failure/schema"
+)
+
+// Property is the key under which field errors are stored on a validation error.
+const Property = "validation"
+
+var (
+	// Invalid is the class of errors carrying field errors
+	Invalid = failure.IllegalArgument.Class("validation",
+		failure.WithDescription("The request contains invalid fields"),
+		failure.WithStatus(http.StatusUnprocessableEntity),
+		failure.WithSchema(schema.Required[Errors](Property)),
+	)
+)
+
+func init() {
+	problem.Extend(func(err *failure.Error, details *problem.Details) {
+		if errs := Of(err); len(errs) > 0 {
+			details.Extensions["errors"] = errs
+		}
+	})
+}
+
+// FieldError describes why a single value of the input is invalid.
+type FieldError struct {
+	Path Path
+	// Rule is the code of the failed rule, such as required or min
+	Rule string
+	// Params are the arguments of the rule, such as the minimum length
+	Params  map[string]any
+	Message string
+}
+
+func (f FieldError) Error() string {
+	if f.Path == "" {
+		return f.Message
+	}
+
+	return string(f.Path) + ": " + f.Message
+}
+
+// Errors is a list of field errors, rendered in JSON as a map of paths to messages.
+type Errors []FieldError
+
+func (e Errors) Error() string {
+	strs := make([]string, len(e))
+	for i, err := range e {
+		strs[i] = err.Error()
+	}
+	return strings.Join(strs, "; ")
+}
+
+// Map groups messages by path.
+func (e Errors) Map() map[string][]string {
+	result := make(map[string][]string, len(e))
+	for _, err := range e {
+		result[string(err.Path)] = append(result[string(err.Path)], err.Message)
+	}
+	return result
+}
+
+// At returns the errors located exactly at path.
+func (e Errors) At(path Path) Errors {
+	result := Errors{}
+	for _, err := range e {
+		if err.Path == path {
+			result = append(result, err)
+		}
+	}
+	return result
+}
+
+// MarshalJSON implements json.Marshaler
+func (e Errors) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.Map())
+}
+
+// Param is an argument of a failed rule.
+type Param struct {
+	Key   string
+	Value any
+}
+
+func With(key string, value any) Param {
+	return Param{Key: key, Value: value}
+}
+
+// Collector accumulates field errors. Collectors returned by At share
+// the errors of their parent, with paths relative to their own location.
+type Collector struct {
+	errors *Errors
+	path   Path
+}
+
+func New() *Collector {
+	return &Collector{errors: &Errors{}}
+}
+
+// At returns a collector adding errors below path.
+func (c *Collector) At(path Path) *Collector {
+	return &Collector{
+		errors: c.errors,
+		path:   c.path.Join(path),
+	}
+}
+
+func (c *Collector) Add(errs ...FieldError) *Collector {
+	for _, err := range errs {
+		err.Path = c.path.Join(err.Path)
+		*c.errors = append(*c.errors, err)
+	}
+	return c
+}
+
+// Fail records that the value at path broke rule.
+func (c *Collector) Fail(path Path, rule, message string, params ...Param) *Collector {
+	err := FieldError{
+		Path:    path,
+		Rule:    rule,
+		Message: message,
+	}
+
+	if len(params) > 0 {
+		err.Params = make(map[string]any, len(params))
+		for _, param := range params {
+			err.Params[param.Key] = param.Value
+		}
+	}
+
+	return c.Add(err)
+}
+
+// Check records a failure unless condition holds, and returns condition.
+func (c *Collector) Check(condition bool, path Path, rule, message string, params ...Param) bool {
+	if !condition {
+		c.Fail(path, rule, message, params...)
+	}
+	return condition
+}
+
+// Merge adds the field errors carried by err below path. Other non-nil errors
+// are recorded at path with the invalid rule.
+func (c *Collector) Merge(path Path, err error) *Collector {
+	if err == nil {
+		return c
+	}
+
+	if errs := Of(err); len(errs) > 0 {
+		c.At(path).Add(errs...)
+		return c
+	}
+
+	var field FieldError
+	if errors.As(err, &field) {
+		c.At(path).Add(field)
+		return c
+	}
+
+	return c.Fail(path, "invalid", err.Error())
+}
+
+func (c *Collector) Len() int {
+	return len(*c.errors)
+}
+
+func (c *Collector) Errors() Errors {
+	return append(Errors(nil), *c.errors...)
+}
+
+// Err returns an error of the Invalid class carrying the collected
+// field errors, or nil when there is none.
+func (c *Collector) Err() error {
+	if c.Len() == 0 {
+		return nil
+	}
+
+	return failure.Builder(Invalid).
+		Message("invalid input").
+		Public("One or more fields are invalid").
+		With(Property, c.Errors()).
+		Build()
+}
+
+// Of returns the field errors carried by err.
+func Of(err error) Errors {
+	errs, _ := failure.Property(err, Property).Value.(Errors)
+	return errs
+}